@@ -0,0 +1,23 @@
+// timefold 命令行工具，领域包在 init 中通过 codec.Register 注册编解码器
+// 内置 N 皇后示例领域（-domain nqueens），使用自己的领域时，复制此文件并以空白导入引入领域包：
+//
+//	import _ "example.com/yourdomain"
+//
+// 领域包需要实现 codec.DomainCodec，参考 examples/nqueens
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	_ "github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/cli"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := cli.Run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
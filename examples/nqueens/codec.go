@@ -0,0 +1,77 @@
+package nqueens
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+)
+
+func init() {
+	codec.Register("nqueens", Codec{})
+}
+
+// Codec N 皇后问题的编解码器，问题与解决方案都是 JSON：
+//
+//	{"n": 8}
+//	{"n": 4, "rows": [1, 3, 0, 2]}
+//
+// rows 按列给出皇后所在的行，null 表示未放置，解决方案额外输出 score
+type Codec struct{}
+
+type boardJSON struct {
+	N     int    `json:"n"`
+	Rows  []*int `json:"rows,omitempty"`
+	Score string `json:"score,omitempty"`
+}
+
+func (Codec) DecodeProblem(r io.Reader) (api.ISolution, error) {
+	var data boardJSON
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, err
+	}
+	if data.N < 1 {
+		return nil, fmt.Errorf("n must be at least 1, got %d", data.N)
+	}
+	if data.Rows != nil && len(data.Rows) != data.N {
+		return nil, fmt.Errorf("rows must have %d entries, got %d", data.N, len(data.Rows))
+	}
+	board := NewBoard(data.N)
+	for column, row := range data.Rows {
+		if row == nil {
+			continue
+		}
+		if *row < 0 || *row >= data.N {
+			return nil, fmt.Errorf("row of column %d must be between 0 and %d, got %d", column, data.N-1, *row)
+		}
+		board.GetQueens()[column].row.value = *row
+	}
+	return board, nil
+}
+
+func (Codec) EncodeSolution(w io.Writer, solution api.ISolution) error {
+	board, ok := solution.(*Board)
+	if !ok {
+		return fmt.Errorf("expected *nqueens.Board, got %T", solution)
+	}
+	data := boardJSON{N: board.N, Rows: make([]*int, 0, board.N)}
+	for _, queen := range board.GetQueens() {
+		if row, ok := queen.GetRow(); ok {
+			data.Rows = append(data.Rows, &row)
+		} else {
+			data.Rows = append(data.Rows, nil)
+		}
+	}
+	if board.score != nil {
+		data.Score = board.score.ToShortString()
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+func (Codec) ConstraintConfigure() api.IConstraintConfigure {
+	return ConstraintManager()
+}
@@ -0,0 +1,157 @@
+// Package nqueens N 皇后问题示例领域：在 N×N 棋盘的每一列放置一个皇后，任意两个皇后不能在同一行或同一对角线上
+// 导入此包即注册名为 "nqueens" 的领域编解码器，命令行工具 cmd/timefold 以空白导入引入
+package nqueens
+
+import (
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+// RowVariable 皇后所在的行，值为 0 到 N-1 的 int，未赋值时为 nil
+type RowVariable struct {
+	value      interface{}
+	valueRange api.IValueRange
+}
+
+func (v *RowVariable) GetValue() interface{} {
+	return v.value
+}
+
+func (v *RowVariable) SetValue(value interface{}) {
+	v.value = value
+}
+
+func (v *RowVariable) GetValueRange() api.IValueRange {
+	return v.valueRange
+}
+
+// Queen 棋盘某一列上的皇后，规划变量为所在的行
+type Queen struct {
+	Column int
+	row    *RowVariable
+}
+
+func (q *Queen) PlanningFilter() {}
+
+func (q *Queen) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{q.row}
+}
+
+// GetRow 获取皇后所在的行，未赋值时返回 false
+func (q *Queen) GetRow() (int, bool) {
+	row, ok := q.row.value.(int)
+	return row, ok
+}
+
+func (q *Queen) String() string {
+	if row, ok := q.GetRow(); ok {
+		return fmt.Sprintf("Queen(column=%d, row=%d)", q.Column, row)
+	}
+	return fmt.Sprintf("Queen(column=%d)", q.Column)
+}
+
+// Board N×N 的棋盘，实现 api.ICloneableSolution 以支持并行评估移动
+type Board struct {
+	N      int
+	queens []api.IPlanningEntity
+	score  api.IScore
+}
+
+// NewBoard 创建每列一个皇后、皇后都未放置的棋盘
+func NewBoard(n int) *Board {
+	rows := make([]interface{}, n)
+	for i := range rows {
+		rows[i] = i
+	}
+	valueRange := valuerange.NewListValueRange(rows...)
+	board := &Board{N: n, queens: make([]api.IPlanningEntity, 0, n)}
+	for column := 0; column < n; column++ {
+		board.queens = append(board.queens, &Queen{Column: column, row: &RowVariable{valueRange: valueRange}})
+	}
+	return board
+}
+
+// GetQueens 获取按列排列的皇后
+func (b *Board) GetQueens() []*Queen {
+	queens := make([]*Queen, 0, len(b.queens))
+	for _, entity := range b.queens {
+		queens = append(queens, entity.(*Queen))
+	}
+	return queens
+}
+
+func (b *Board) GetScore() api.IScore {
+	return b.score
+}
+
+func (b *Board) SetScore(score api.IScore) {
+	b.score = score
+}
+
+func (b *Board) GetPlanningEntities() []api.IPlanningEntity {
+	return b.queens
+}
+
+func (b *Board) SetPlanningEntities(entities []api.IPlanningEntity) {
+	b.queens = entities
+}
+
+func (b *Board) GetProblemFacts() []interface{} {
+	return nil
+}
+
+func (b *Board) SetProblemFacts(facts []interface{}) {}
+
+func (b *Board) CloneSolution() api.ISolution {
+	clone := &Board{N: b.N, queens: make([]api.IPlanningEntity, 0, len(b.queens)), score: b.score}
+	for _, queen := range b.GetQueens() {
+		clone.queens = append(clone.queens, &Queen{
+			Column: queen.Column,
+			row:    &RowVariable{value: queen.row.value, valueRange: queen.row.valueRange},
+		})
+	}
+	return clone
+}
+
+// ConstraintManager 创建 N 皇后问题的约束：同一行与同一对角线上的每对皇后惩罚 1 个硬分数
+func ConstraintManager() *constraint.ConstraintManager {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(conflictConstraint("row conflict", func(a, b *Queen, rowA, rowB int) bool {
+		return rowA == rowB
+	}))
+	cm.AddConstraint(conflictConstraint("ascending diagonal conflict", func(a, b *Queen, rowA, rowB int) bool {
+		return rowA-a.Column == rowB-b.Column
+	}))
+	cm.AddConstraint(conflictConstraint("descending diagonal conflict", func(a, b *Queen, rowA, rowB int) bool {
+		return rowA+a.Column == rowB+b.Column
+	}))
+	return cm
+}
+
+// conflictConstraint 创建按冲突的皇后对计数的硬约束
+func conflictConstraint(name string, conflicts func(a, b *Queen, rowA, rowB int) bool) *constraint.Constraint {
+	return constraint.NewConstraint(
+		constraint.WithName(name),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.HARD),
+		constraint.WithMatchWeightFunc(func(solution api.ISolution) int {
+			queens := solution.(*Board).GetQueens()
+			count := 0
+			for i, a := range queens {
+				rowA, ok := a.GetRow()
+				if !ok {
+					continue
+				}
+				for _, b := range queens[i+1:] {
+					if rowB, ok := b.GetRow(); ok && conflicts(a, b, rowA, rowB) {
+						count++
+					}
+				}
+			}
+			return count
+		}),
+	)
+}
@@ -1,3 +1,5 @@
 module github.com/kruily/go-timefold-solver
 
 go 1.23.4
+
+require gopkg.in/yaml.v3 v3.0.1
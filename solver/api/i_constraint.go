@@ -2,6 +2,8 @@ package api
 
 // 约束接口
 type IConstraint interface {
+	// 获取约束名称
+	GetName() string
 	// 获取约束的得分
 	GetScore() IScore
 	// 检查约束是否满足
@@ -9,3 +11,34 @@ type IConstraint interface {
 	// 获取约束权重
 	GetWeight() int
 }
+
+// 带匹配权重的约束接口
+// 分数为单位分数乘以约束权重再乘以匹配权重，未实现时满足的约束匹配权重为 1
+type IWeightedConstraint interface {
+	IConstraint
+	// MatchWeight 获取匹配权重之和，如加班的分钟数，未匹配时为 0
+	MatchWeight(solution ISolution) int
+}
+
+// 提供匹配说明的约束接口
+// 分数明细按匹配列出权重与说明对象，未实现时整个约束作为一次没有说明对象的匹配
+type IJustifiedConstraint interface {
+	IConstraint
+	// Matches 获取每次匹配的权重与说明对象，权重之和等于匹配权重，未匹配时为空
+	Matches(solution ISolution) []ConstraintMatch
+}
+
+// 属于包（分组）的约束接口
+type IPackagedConstraint interface {
+	IConstraint
+	// GetPackage 获取约束所属的包，未设置时为空
+	GetPackage() string
+}
+
+// ConstraintMatch 约束的单次匹配
+type ConstraintMatch struct {
+	// 匹配权重，如加班的分钟数
+	Weight int
+	// 匹配的说明对象，如 OverlapJustification{ShiftA, ShiftB}
+	Justification interface{}
+}
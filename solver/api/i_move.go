@@ -9,3 +9,19 @@ type IMove interface {
 	// 接受移动
 	Accept(scoreDirector IScoreDirector) bool
 }
+
+// 工作对象查找接口，将一个工作解决方案中的实体与变量映射到另一个工作解决方案
+type IWorkingObjectLookup interface {
+	// LookUpEntity 查找对应的规划实体，找不到时返回 nil
+	LookUpEntity(entity IPlanningEntity) IPlanningEntity
+	// LookUpVariable 查找对应的规划变量，找不到时返回 nil
+	LookUpVariable(variable IPlanningVariable) IPlanningVariable
+}
+
+// 可转移的移动接口
+// 多线程评估移动时需要把移动转移到各线程的克隆解决方案上
+type IRebasableMove interface {
+	IMove
+	// Rebase 创建作用于目标解决方案的相同移动
+	Rebase(lookup IWorkingObjectLookup, scoreDirector IScoreDirector) IMove
+}
@@ -4,6 +4,8 @@ package api
 type IPlanningEntity interface {
 	// PlanningFilter 获取实体的规划过滤器
 	PlanningFilter()
+	// GetPlanningVariables 获取实体的所有规划变量
+	GetPlanningVariables() []IPlanningVariable
 }
 
 // 规划实体注解
@@ -8,3 +8,16 @@ type IPlanningVariable interface {
 	// GetValueRange 获取变量的可能值范围
 	GetValueRange() IValueRange
 }
+
+// 规划变量注解
+type PlanningVariable interface {
+	// 获取值的强度比较器
+	StrengthComparatorClass() IComparator[interface{}]
+}
+
+// 可为空的规划变量注解
+// 可为空的变量允许保持未赋值（过约束规划），未赋值时不计入初始化分数
+type NullablePlanningVariable interface {
+	// 是否允许变量保持未赋值
+	IsNullable() bool
+}
@@ -1,5 +1,7 @@
 package api
 
+import "context"
+
 // 分数指导器接口 负责计算和缓存分数
 type IScoreDirector interface {
 	// Calculate 计算完整解决方案的得分
@@ -13,3 +15,44 @@ type IScoreDirector interface {
 	// SetWorkingSolution 设置当前工作解决方案
 	SetWorkingSolution(solution ISolution)
 }
+
+// 支持取消的分数指导器接口
+type IContextScoreDirector interface {
+	IScoreDirector
+	// CalculateContext 计算完整解决方案的得分，上下文取消时返回上下文的错误
+	CalculateContext(ctx context.Context, solution ISolution) (IScore, error)
+}
+
+// 可克隆的分数指导器接口
+// 多线程评估移动时每个线程使用独立的分数指导器
+type ICloneableScoreDirector interface {
+	IScoreDirector
+	// CloneScoreDirector 创建使用相同约束的新分数指导器，不共享工作解决方案与缓存
+	CloneScoreDirector() IScoreDirector
+}
+
+// 提供乐观边界的分数指导器接口
+// 分支定界使用乐观边界剪枝，边界不能低于补全部分解决方案后可能得到的任何分数
+type IOptimisticBoundScoreDirector interface {
+	IScoreDirector
+	// CalculateOptimisticBound 计算部分初始化的解决方案在补全后可能达到的最高分数
+	CalculateOptimisticBound(solution ISolution) IScore
+}
+
+// 可重置的分数指导器接口
+// 问题变更（如增删实体）后丢弃缓存的增量状态，从新的工作解决方案重新开始
+type IResettableScoreDirector interface {
+	IScoreDirector
+	// ResetWorkingSolution 设置工作解决方案并丢弃增量状态
+	ResetWorkingSolution(solution ISolution)
+}
+
+// 可重新配置约束的分数指导器接口
+// 约束权重覆盖在求解开始前替换分数指导器使用的约束配置
+type IConstraintConfigurableScoreDirector interface {
+	IScoreDirector
+	// GetConstraintManager 获取约束配置
+	GetConstraintManager() IConstraintConfigure
+	// SetConstraintManager 替换约束配置并丢弃增量状态
+	SetConstraintManager(constraintManager IConstraintConfigure)
+}
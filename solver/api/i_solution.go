@@ -16,3 +16,11 @@ type ISolution interface {
 	// SetProblemFacts 设置问题事实（不可变的问题数据）
 	SetProblemFacts(facts []interface{})
 }
+
+// 可克隆的解决方案接口
+// 多线程评估移动时每个线程在独立的克隆上执行移动
+type ICloneableSolution interface {
+	ISolution
+	// CloneSolution 深拷贝解决方案，克隆中规划实体与规划变量的顺序必须与原解决方案一致
+	CloneSolution() ISolution
+}
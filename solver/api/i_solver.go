@@ -1,9 +1,13 @@
 package api
 
+import "context"
+
 // 规划求解器接口
 type ISolver interface {
 	// 求解问题
 	Solve(problem ISolution) (ISolution, error)
+	// 求解问题，上下文取消或超时时返回目前的最佳解决方案与上下文的错误
+	SolveContext(ctx context.Context, problem ISolution) (ISolution, error)
 	// 停止求解
 	Stop()
 	// 是否终止
@@ -1,7 +1,28 @@
 package api
 
+import "math/rand"
+
 // 值范围接口
 type IValueRange interface {
 	// CreateIterator 创建一个迭代器来遍历可能的值
 	CreateIterator() IValueRangeIterator
 }
+
+// 可数值范围接口 支持大小、随机访问与随机选择
+type ICountableValueRange interface {
+	IValueRange
+	// GetSize 获取值的数量
+	GetSize() int64
+	// Get 获取指定下标的值
+	Get(index int64) interface{}
+	// Contains 是否包含指定值
+	Contains(value interface{}) bool
+	// CreateRandomIterator 创建一个随机选择值的迭代器，值范围非空时永不结束
+	CreateRandomIterator(random *rand.Rand) IValueRangeIterator
+}
+
+// 实体值范围提供者 实体实现此接口时为其规划变量提供实体特定的值范围
+type IEntityValueRangeProvider interface {
+	// GetValueRange 获取实体上指定规划变量的值范围
+	GetValueRange(variable IPlanningVariable) IValueRange
+}
@@ -0,0 +1,252 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+const (
+	// WriteFiles 生成的报告文件名
+	HTMLReportFileName = "index.html"
+	JSONReportFileName = "summary.json"
+)
+
+// BenchmarkReport 基准测试报告
+type BenchmarkReport struct {
+	StartTime      time.Time `json:"start_time"`
+	DurationMillis int64     `json:"duration_millis"`
+	RepeatCount    int       `json:"repeat_count"`
+	Datasets       []string  `json:"datasets"`
+	// 排名第一的求解器，所有运行都失败时为空
+	Winner  string          `json:"winner"`
+	Solvers []*SolverResult `json:"solvers"`
+}
+
+// SolverResult 单个求解器配置在所有数据集上的结果
+type SolverResult struct {
+	Name string `json:"name"`
+	// 排名，从 1 开始
+	Rank int `json:"rank"`
+	// 所有数据集所有运行的最佳分数之和，用于排名
+	TotalScore string `json:"total_score"`
+	// 平均分数最高的数据集数
+	WinCount int              `json:"win_count"`
+	Datasets []*DatasetResult `json:"datasets"`
+
+	totalScore api.IScore
+}
+
+// DatasetResult 单个求解器配置在单个数据集上的结果
+type DatasetResult struct {
+	Dataset      string `json:"dataset"`
+	BestScore    string `json:"best_score"`
+	AverageScore string `json:"average_score"`
+	// 是否为该数据集上平均分数最高的求解器
+	Winner                       bool         `json:"winner"`
+	AverageTimeSpentMillis       int64        `json:"average_time_spent_millis"`
+	AverageStepCount             int          `json:"average_step_count"`
+	AverageMoveCount             int64        `json:"average_move_count"`
+	AverageScoreCalculationSpeed float64      `json:"average_score_calculation_speed"`
+	FailedRunCount               int          `json:"failed_run_count"`
+	Runs                         []*RunResult `json:"runs"`
+
+	bestScore  api.IScore
+	totalScore api.IScore
+}
+
+// RunResult 单次运行的结果
+type RunResult struct {
+	Seed              int64  `json:"seed"`
+	BestScore         string `json:"best_score"`
+	TerminationReason string `json:"termination_reason"`
+	// 求解器返回的错误，如检测到分数损坏
+	Error                 string  `json:"error,omitempty"`
+	TimeSpentMillis       int64   `json:"time_spent_millis"`
+	StepCount             int     `json:"step_count"`
+	MoveCount             int64   `json:"move_count"`
+	ScoreCalculationCount int64   `json:"score_calculation_count"`
+	ScoreCalculationSpeed float64 `json:"score_calculation_speed"`
+	// 最佳分数随时间的变化
+	BestScoreHistory []ScorePoint `json:"best_score_history"`
+
+	bestScore api.IScore
+}
+
+// ScorePoint 某一时刻的最佳分数
+type ScorePoint struct {
+	TimeMillis int64     `json:"time_millis"`
+	Score      string    `json:"score"`
+	Levels     []float64 `json:"levels"`
+}
+
+// summarize 汇总数据集上所有运行的结果
+func (d *DatasetResult) summarize() {
+	var scored int
+	var timeSpent, moveCount int64
+	var stepCount int
+	var speed float64
+	for _, run := range d.Runs {
+		timeSpent += run.TimeSpentMillis
+		stepCount += run.StepCount
+		moveCount += run.MoveCount
+		speed += run.ScoreCalculationSpeed
+		if run.Error != "" {
+			d.FailedRunCount++
+		}
+		if run.bestScore == nil {
+			continue
+		}
+		scored++
+		if d.bestScore == nil || run.bestScore.CompareTo(d.bestScore) > 0 {
+			d.bestScore = run.bestScore
+		}
+		if d.totalScore == nil {
+			d.totalScore = run.bestScore
+		} else {
+			d.totalScore = d.totalScore.Add(run.bestScore)
+		}
+	}
+	if count := len(d.Runs); count > 0 {
+		d.AverageTimeSpentMillis = timeSpent / int64(count)
+		d.AverageStepCount = stepCount / count
+		d.AverageMoveCount = moveCount / int64(count)
+		d.AverageScoreCalculationSpeed = speed / float64(count)
+	}
+	if scored > 0 {
+		d.BestScore = d.bestScore.ToShortString()
+		d.AverageScore = d.totalScore.Divide(float64(scored)).ToShortString()
+	}
+}
+
+// isComplete 是否所有运行都得到了分数，缺少分数的结果不参与比较
+func (d *DatasetResult) isComplete(repeatCount int) bool {
+	if len(d.Runs) != repeatCount {
+		return false
+	}
+	for _, run := range d.Runs {
+		if run.bestScore == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// rank 按所有运行的最佳分数之和排名，分数相同时获胜的数据集多者靠前
+// 每个数据集的运行次数相同，分数之和的顺序与平均分数的顺序一致
+func (r *BenchmarkReport) rank() {
+	for i := range r.Datasets {
+		var winners []*DatasetResult
+		for _, s := range r.Solvers {
+			d := s.Datasets[i]
+			if !d.isComplete(r.RepeatCount) {
+				continue
+			}
+			switch {
+			case len(winners) == 0:
+				winners = []*DatasetResult{d}
+			case d.totalScore.CompareTo(winners[0].totalScore) > 0:
+				winners = []*DatasetResult{d}
+			case d.totalScore.CompareTo(winners[0].totalScore) == 0:
+				winners = append(winners, d)
+			}
+		}
+		for _, d := range winners {
+			d.Winner = true
+		}
+	}
+
+	ranked := make([]*SolverResult, 0, len(r.Solvers))
+	for _, s := range r.Solvers {
+		complete := true
+		for _, d := range s.Datasets {
+			if d.Winner {
+				s.WinCount++
+			}
+			if !d.isComplete(r.RepeatCount) {
+				complete = false
+				continue
+			}
+			if s.totalScore == nil {
+				s.totalScore = d.totalScore
+			} else {
+				s.totalScore = s.totalScore.Add(d.totalScore)
+			}
+		}
+		if !complete || s.totalScore == nil {
+			// 没有完成所有运行的求解器不参与排名
+			s.totalScore = nil
+			continue
+		}
+		s.TotalScore = s.totalScore.ToShortString()
+		ranked = append(ranked, s)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if c := ranked[i].totalScore.CompareTo(ranked[j].totalScore); c != 0 {
+			return c > 0
+		}
+		return ranked[i].WinCount > ranked[j].WinCount
+	})
+	for i, s := range ranked {
+		s.Rank = i + 1
+	}
+	if len(ranked) > 0 {
+		r.Winner = ranked[0].Name
+	}
+}
+
+// GetRanking 按排名获取求解器结果，未参与排名的求解器不包括在内
+func (r *BenchmarkReport) GetRanking() []*SolverResult {
+	ranked := make([]*SolverResult, 0, len(r.Solvers))
+	for _, s := range r.Solvers {
+		if s.Rank > 0 {
+			ranked = append(ranked, s)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Rank < ranked[j].Rank
+	})
+	return ranked
+}
+
+// WriteJSON 输出 JSON 格式的报告
+func (r *BenchmarkReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("write json report: %w", err)
+	}
+	return nil
+}
+
+// WriteFiles 在目录中生成 HTML 报告与 JSON 汇总，目录不存在时创建
+func (r *BenchmarkReport) WriteFiles(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create report directory: %w", err)
+	}
+	if err := writeFile(filepath.Join(dir, HTMLReportFileName), r.WriteHTML); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, JSONReportFileName), r.WriteJSON)
+}
+
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create report file: %w", err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close report file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package benchmark
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strings"
+)
+
+// 图表尺寸与边距
+const (
+	chartWidth   = 720
+	chartHeight  = 300
+	chartPadding = 50
+)
+
+var chartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b", "#e377c2", "#7f7f7f", "#bcbd22", "#17becf"}
+
+// WriteHTML 输出独立的 HTML 报告，图表以内联 SVG 绘制，不依赖外部资源
+func (r *BenchmarkReport) WriteHTML(w io.Writer) error {
+	if err := reportTemplate.Execute(w, r.htmlData()); err != nil {
+		return fmt.Errorf("write html report: %w", err)
+	}
+	return nil
+}
+
+type htmlReport struct {
+	*BenchmarkReport
+	Ranking  []*SolverResult
+	Unranked []*SolverResult
+	Sections []htmlDataset
+}
+
+type htmlDataset struct {
+	Name    string
+	Results []htmlDatasetResult
+	Charts  []htmlChart
+	Speed   htmlChart
+}
+
+type htmlDatasetResult struct {
+	Solver string
+	*DatasetResult
+}
+
+type htmlChart struct {
+	Title  string
+	XLabel string
+	YMin   string
+	YMax   string
+	XMax   string
+	Series []htmlSeries
+	Bars   []htmlBar
+}
+
+type htmlSeries struct {
+	Name   string
+	Color  string
+	Points string
+}
+
+type htmlBar struct {
+	Name   string
+	Color  string
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+	Label  string
+}
+
+func (r *BenchmarkReport) htmlData() *htmlReport {
+	data := &htmlReport{BenchmarkReport: r, Ranking: r.GetRanking()}
+	for _, s := range r.Solvers {
+		if s.Rank == 0 {
+			data.Unranked = append(data.Unranked, s)
+		}
+	}
+	for i, name := range r.Datasets {
+		dataset := htmlDataset{Name: name}
+		for _, s := range r.Solvers {
+			dataset.Results = append(dataset.Results, htmlDatasetResult{Solver: s.Name, DatasetResult: s.Datasets[i]})
+		}
+		dataset.Charts = bestScoreCharts(dataset.Results)
+		dataset.Speed = speedChart(dataset.Results)
+		data.Sections = append(data.Sections, dataset)
+	}
+	return data
+}
+
+// bestScoreCharts 为每个分数级别绘制第一次运行的最佳分数随时间变化的折线图，所有求解器上都不变的级别不绘制
+func bestScoreCharts(results []htmlDatasetResult) []htmlChart {
+	levelCount := 0
+	var maxTime int64 = 1
+	for _, result := range results {
+		if len(result.Runs) == 0 {
+			continue
+		}
+		run := result.Runs[0]
+		maxTime = max(maxTime, run.TimeSpentMillis)
+		for _, point := range run.BestScoreHistory {
+			levelCount = max(levelCount, len(point.Levels))
+		}
+	}
+
+	charts := make([]htmlChart, 0, levelCount)
+	for level := 0; level < levelCount; level++ {
+		yMin, yMax := math.Inf(1), math.Inf(-1)
+		for _, result := range results {
+			if len(result.Runs) == 0 {
+				continue
+			}
+			for _, point := range result.Runs[0].BestScoreHistory {
+				if level < len(point.Levels) {
+					yMin = math.Min(yMin, point.Levels[level])
+					yMax = math.Max(yMax, point.Levels[level])
+				}
+			}
+		}
+		if math.IsInf(yMin, 0) || yMin == yMax {
+			continue
+		}
+		chart := htmlChart{
+			Title:  fmt.Sprintf("Best score level %d over time", level),
+			XLabel: "time (ms)",
+			YMin:   formatNumber(yMin),
+			YMax:   formatNumber(yMax),
+			XMax:   fmt.Sprint(maxTime),
+		}
+		for i, result := range results {
+			if len(result.Runs) == 0 {
+				continue
+			}
+			run := result.Runs[0]
+			chart.Series = append(chart.Series, htmlSeries{
+				Name:   result.Solver,
+				Color:  chartColors[i%len(chartColors)],
+				Points: stepLine(run, level, maxTime, yMin, yMax),
+			})
+		}
+		charts = append(charts, chart)
+	}
+	return charts
+}
+
+// stepLine 将最佳分数的变化转换为阶梯折线，延伸到运行结束的时间
+func stepLine(run *RunResult, level int, maxTime int64, yMin, yMax float64) string {
+	var b strings.Builder
+	var lastY float64
+	hasPoint := false
+	for _, point := range run.BestScoreHistory {
+		if level >= len(point.Levels) {
+			continue
+		}
+		x := scaleX(point.TimeMillis, maxTime)
+		y := scaleY(point.Levels[level], yMin, yMax)
+		if hasPoint {
+			fmt.Fprintf(&b, "%.1f,%.1f ", x, lastY)
+		}
+		fmt.Fprintf(&b, "%.1f,%.1f ", x, y)
+		lastY = y
+		hasPoint = true
+	}
+	if hasPoint {
+		fmt.Fprintf(&b, "%.1f,%.1f", scaleX(run.TimeSpentMillis, maxTime), lastY)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// speedChart 绘制每个求解器的平均分数计算速度柱状图
+func speedChart(results []htmlDatasetResult) htmlChart {
+	maxSpeed := 0.0
+	for _, result := range results {
+		maxSpeed = math.Max(maxSpeed, result.AverageScoreCalculationSpeed)
+	}
+	chart := htmlChart{Title: "Average score calculation speed (per second)", YMin: "0", YMax: formatNumber(maxSpeed)}
+	if len(results) == 0 || maxSpeed == 0 {
+		return chart
+	}
+	slot := float64(chartWidth-2*chartPadding) / float64(len(results))
+	for i, result := range results {
+		height := result.AverageScoreCalculationSpeed / maxSpeed * float64(chartHeight-2*chartPadding)
+		chart.Bars = append(chart.Bars, htmlBar{
+			Name:   result.Solver,
+			Color:  chartColors[i%len(chartColors)],
+			X:      chartPadding + float64(i)*slot + slot*0.1,
+			Y:      float64(chartHeight-chartPadding) - height,
+			Width:  slot * 0.8,
+			Height: height,
+			Label:  formatNumber(result.AverageScoreCalculationSpeed),
+		})
+	}
+	return chart
+}
+
+func scaleX(timeMillis, maxTime int64) float64 {
+	return chartPadding + float64(timeMillis)/float64(maxTime)*float64(chartWidth-2*chartPadding)
+}
+
+func scaleY(value, yMin, yMax float64) float64 {
+	return float64(chartHeight-chartPadding) - (value-yMin)/(yMax-yMin)*float64(chartHeight-2*chartPadding)
+}
+
+func formatNumber(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"number": formatNumber,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Benchmark report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+tr.winner td { font-weight: bold; background: #eef7ee; }
+svg { background: #fafafa; border: 1px solid #ddd; margin: 0 1em 1em 0; }
+.legend span { display: inline-block; margin-right: 1em; }
+.error { color: #b00; }
+</style>
+</head>
+<body>
+<h1>Benchmark report</h1>
+<p>Started {{.StartTime.Format "2006-01-02 15:04:05"}}, took {{.DurationMillis}} ms, {{.RepeatCount}} run(s) per solver and dataset.</p>
+{{if .Winner}}<p>Winner: <strong>{{.Winner}}</strong></p>{{end}}
+
+<h2>Ranking</h2>
+<table>
+<tr><th>Solver</th><th>Rank</th><th>Total score</th><th>Datasets won</th></tr>
+{{range .Ranking}}<tr{{if eq .Rank 1}} class="winner"{{end}}><td>{{.Name}}</td><td>{{.Rank}}</td><td>{{.TotalScore}}</td><td>{{.WinCount}}</td></tr>
+{{end}}{{range .Unranked}}<tr><td>{{.Name}}</td><td>-</td><td class="error">incomplete</td><td>{{.WinCount}}</td></tr>
+{{end}}</table>
+
+{{range .Sections}}
+<h2>Dataset {{.Name}}</h2>
+<table>
+<tr><th>Solver</th><th>Best score</th><th>Average score</th><th>Time (ms)</th><th>Steps</th><th>Moves</th><th>Score calc/s</th><th>Failed runs</th></tr>
+{{range .Results}}<tr{{if .Winner}} class="winner"{{end}}><td>{{.Solver}}</td><td>{{.BestScore}}</td><td>{{.AverageScore}}</td><td>{{.AverageTimeSpentMillis}}</td><td>{{.AverageStepCount}}</td><td>{{.AverageMoveCount}}</td><td>{{number .AverageScoreCalculationSpeed}}</td><td>{{.FailedRunCount}}</td></tr>
+{{end}}</table>
+{{range .Results}}{{$solver := .Solver}}{{range .Runs}}{{if .Error}}<p class="error">{{$solver}} (seed {{.Seed}}): {{.Error}}</p>
+{{end}}{{end}}{{end}}
+{{range .Charts}}
+<h3>{{.Title}}</h3>
+<svg width="720" height="300" viewBox="0 0 720 300">
+<line x1="50" y1="250" x2="670" y2="250" stroke="#999"/><line x1="50" y1="50" x2="50" y2="250" stroke="#999"/>
+<text x="45" y="55" text-anchor="end" font-size="11">{{.YMax}}</text><text x="45" y="250" text-anchor="end" font-size="11">{{.YMin}}</text>
+<text x="50" y="265" font-size="11">0</text><text x="670" y="265" text-anchor="end" font-size="11">{{.XMax}}</text>
+<text x="360" y="285" text-anchor="middle" font-size="11">{{.XLabel}}</text>
+{{range .Series}}<polyline fill="none" stroke="{{.Color}}" stroke-width="2" points="{{.Points}}"/>
+{{end}}</svg>
+<div class="legend">{{range .Series}}<span style="color: {{.Color}}">&#9632; {{.Name}}</span>{{end}}</div>
+{{end}}
+{{with .Speed}}{{if .Bars}}
+<h3>{{.Title}}</h3>
+<svg width="720" height="300" viewBox="0 0 720 300">
+<line x1="50" y1="250" x2="670" y2="250" stroke="#999"/>
+{{range .Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" fill="{{.Color}}"/>
+<text x="{{.X}}" y="{{.Y}}" dy="-4" font-size="11">{{.Label}}</text>
+<text x="{{.X}}" y="265" font-size="11">{{.Name}}</text>
+{{end}}</svg>
+{{end}}{{end}}
+{{end}}
+</body>
+</html>
+`))
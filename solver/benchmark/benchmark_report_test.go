@@ -0,0 +1,185 @@
+package benchmark
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	hardsoft "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+)
+
+func soft(score int) api.IScore {
+	return hardsoft.NewHardSoftScore(0, 0, score)
+}
+
+// solverResult 创建求解器结果，每个参数为一个数据集上各次运行的最佳分数，nil 表示运行失败
+func solverResult(name string, datasets ...[]api.IScore) *SolverResult {
+	s := &SolverResult{Name: name}
+	for i, scores := range datasets {
+		d := &DatasetResult{Dataset: fmt.Sprintf("dataset %d", i)}
+		for seed, score := range scores {
+			run := &RunResult{Seed: int64(seed), bestScore: score}
+			if score == nil {
+				run.Error = "solver failed"
+			} else {
+				run.BestScore = score.ToShortString()
+			}
+			d.Runs = append(d.Runs, run)
+		}
+		d.summarize()
+		s.Datasets = append(s.Datasets, d)
+	}
+	return s
+}
+
+func rankedReport(repeatCount int, solvers ...*SolverResult) *BenchmarkReport {
+	report := &BenchmarkReport{RepeatCount: repeatCount, Solvers: solvers}
+	for i := range solvers[0].Datasets {
+		report.Datasets = append(report.Datasets, fmt.Sprintf("dataset %d", i))
+	}
+	report.rank()
+	return report
+}
+
+func rankingNames(report *BenchmarkReport) []string {
+	var names []string
+	for _, s := range report.GetRanking() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestRankBySummedBestScores(t *testing.T) {
+	a := solverResult("a", []api.IScore{soft(-1), soft(-3)}, []api.IScore{soft(-2), soft(-2)})
+	b := solverResult("b", []api.IScore{soft(-2), soft(-2)}, []api.IScore{soft(-1), soft(-1)})
+	// 单次运行的最佳分数最高，但分数之和最低
+	c := solverResult("c", []api.IScore{soft(-10), soft(0)}, []api.IScore{soft(-2), soft(-2)})
+	report := rankedReport(2, a, b, c)
+
+	if got := rankingNames(report); fmt.Sprint(got) != "[b a c]" {
+		t.Errorf("got ranking %v, want [b a c]", got)
+	}
+	if report.Winner != "b" {
+		t.Errorf("got winner %q, want b", report.Winner)
+	}
+	if b.TotalScore != "HardSoftScore[initScore=0, hardScore=0, softScore=-6]" {
+		t.Errorf("got total score %s for b", b.TotalScore)
+	}
+	// 第一个数据集上 a 与 b 的分数之和相同，都是获胜者
+	for _, s := range []*SolverResult{a, b} {
+		if !s.Datasets[0].Winner {
+			t.Errorf("%s did not win the tied dataset", s.Name)
+		}
+	}
+	if a.WinCount != 1 || b.WinCount != 2 || c.WinCount != 0 {
+		t.Errorf("got win counts %d, %d, %d, want 1, 2, 0", a.WinCount, b.WinCount, c.WinCount)
+	}
+	if d := c.Datasets[0]; d.BestScore != soft(0).ToShortString() || d.AverageScore != soft(-5).ToShortString() {
+		t.Errorf("got best score %s and average score %s", d.BestScore, d.AverageScore)
+	}
+}
+
+func TestRankBreaksTiesByWinCount(t *testing.T) {
+	a := solverResult("a", []api.IScore{soft(-1)}, []api.IScore{soft(-5)})
+	b := solverResult("b", []api.IScore{soft(-3)}, []api.IScore{soft(-3)})
+	c := solverResult("c", []api.IScore{soft(-2)}, []api.IScore{soft(-4)})
+	report := rankedReport(1, c, b, a)
+	// 分数之和都相同，a 与 b 各赢一个数据集，顺序与添加的顺序一致
+	if got := rankingNames(report); fmt.Sprint(got) != "[b a c]" {
+		t.Errorf("got ranking %v, want [b a c]", got)
+	}
+
+	same := solverResult("same", []api.IScore{soft(-1)})
+	other := solverResult("other", []api.IScore{soft(-1)})
+	report = rankedReport(1, same, other)
+	if same.Rank != 1 || other.Rank != 2 || !same.Datasets[0].Winner || !other.Datasets[0].Winner {
+		t.Errorf("identical results: got ranks %d and %d", same.Rank, other.Rank)
+	}
+}
+
+func TestRankRequiresCompleteRuns(t *testing.T) {
+	complete := solverResult("complete", []api.IScore{soft(-5), soft(-5)}, []api.IScore{soft(-5), soft(-5)})
+	failed := solverResult("failed", []api.IScore{soft(-1), nil}, []api.IScore{soft(-1), soft(-1)})
+	canceled := solverResult("canceled", []api.IScore{soft(0)}, []api.IScore{})
+	report := rankedReport(2, complete, failed, canceled)
+
+	if got := rankingNames(report); fmt.Sprint(got) != "[complete]" {
+		t.Errorf("got ranking %v, want only the complete solver", got)
+	}
+	for _, s := range []*SolverResult{failed, canceled} {
+		if s.Rank != 0 || s.TotalScore != "" {
+			t.Errorf("%s: got rank %d and total score %q, want it unranked", s.Name, s.Rank, s.TotalScore)
+		}
+	}
+	// 缺少分数的数据集不参与比较，完整的数据集仍然可以获胜
+	if !complete.Datasets[0].Winner || failed.Datasets[0].Winner || canceled.Datasets[0].Winner {
+		t.Errorf("incomplete results took part in the first dataset")
+	}
+	if !failed.Datasets[1].Winner || failed.WinCount != 1 {
+		t.Errorf("failed solver did not win its complete dataset")
+	}
+	if d := failed.Datasets[0]; d.FailedRunCount != 1 || d.BestScore != soft(-1).ToShortString() {
+		t.Errorf("got %d failed runs and best score %s", d.FailedRunCount, d.BestScore)
+	}
+
+	report = rankedReport(2, solverResult("failed", []api.IScore{nil, nil}))
+	if report.Winner != "" || len(report.GetRanking()) != 0 {
+		t.Errorf("got winner %q when every run failed", report.Winner)
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	report := rankedReport(2,
+		solverResult("first", []api.IScore{soft(-2), soft(-2)}),
+		solverResult("second", []api.IScore{soft(-1), nil}),
+	)
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var decoded BenchmarkReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Winner != "first" || len(decoded.Solvers) != 2 || decoded.Solvers[0].Rank != 1 || decoded.Solvers[1].Rank != 0 {
+		t.Errorf("decoded report: got winner %q and solvers %+v", decoded.Winner, decoded.Solvers)
+	}
+	runs := decoded.Solvers[1].Datasets[0].Runs
+	if len(runs) != 2 || runs[0].BestScore != soft(-1).ToShortString() || runs[1].Error != "solver failed" {
+		t.Errorf("decoded runs: got %+v", runs)
+	}
+
+	dir := filepath.Join(t.TempDir(), "report")
+	if err := report.WriteFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	summary, err := os.ReadFile(filepath.Join(dir, JSONReportFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(summary, buf.Bytes()) {
+		t.Errorf("%s differs from WriteJSON", JSONReportFileName)
+	}
+	html, err := os.ReadFile(filepath.Join(dir, HTMLReportFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Winner: <strong>first</strong>", "<td>second</td><td>-</td><td class=\"error\">incomplete</td>", "dataset 0"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("%s does not contain %q", HTMLReportFileName, want)
+		}
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := report.WriteFiles(filepath.Join(file, "report")); err == nil || !strings.Contains(err.Error(), "create report directory") {
+		t.Errorf("got error %v when the directory cannot be created", err)
+	}
+}
@@ -0,0 +1,202 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/solver"
+)
+
+// SolverBenchmark 参与比较的求解器配置
+type SolverBenchmark struct {
+	Name   string
+	Config *config.SolverConfig
+}
+
+// Dataset 基准测试的数据集，每次运行前调用 NewProblem 创建新的问题实例
+type Dataset struct {
+	Name       string
+	NewProblem func() api.ISolution
+}
+
+// BenchmarkerOption 基准测试选项
+type BenchmarkerOption func(*Benchmarker)
+
+// Benchmarker 在每个数据集上以不同的随机种子重复运行每个求解器配置，收集统计并生成报告
+type Benchmarker struct {
+	solvers  []SolverBenchmark
+	datasets []Dataset
+	// 每个求解器在每个数据集上的运行次数
+	repeatCount int
+	// 每次运行创建新的分数指导器，避免运行之间共享增量缓存
+	newScoreDirector func() api.IScoreDirector
+	// 创建求解器后的自定义设置，如距离度量、分区器
+	customizer func(*solver.DefaultSolver)
+}
+
+// NewBenchmarker 创建基准测试，newScoreDirector 在每次运行前调用
+func NewBenchmarker(newScoreDirector func() api.IScoreDirector, options ...BenchmarkerOption) *Benchmarker {
+	benchmarker := &Benchmarker{
+		repeatCount:      1,
+		newScoreDirector: newScoreDirector,
+	}
+	for _, option := range options {
+		option(benchmarker)
+	}
+	return benchmarker
+}
+
+// WithSolver 添加求解器配置
+func WithSolver(name string, cfg *config.SolverConfig) BenchmarkerOption {
+	return func(benchmarker *Benchmarker) {
+		benchmarker.solvers = append(benchmarker.solvers, SolverBenchmark{Name: name, Config: cfg})
+	}
+}
+
+// WithDataset 添加数据集
+func WithDataset(name string, newProblem func() api.ISolution) BenchmarkerOption {
+	return func(benchmarker *Benchmarker) {
+		benchmarker.datasets = append(benchmarker.datasets, Dataset{Name: name, NewProblem: newProblem})
+	}
+}
+
+// WithRepeatCount 设置每个求解器在每个数据集上的运行次数，第 i 次运行的随机种子为 RandomSeed + i
+func WithRepeatCount(repeatCount int) BenchmarkerOption {
+	return func(benchmarker *Benchmarker) {
+		benchmarker.repeatCount = repeatCount
+	}
+}
+
+// WithSolverCustomizer 设置创建求解器后的自定义设置
+func WithSolverCustomizer(customizer func(*solver.DefaultSolver)) BenchmarkerOption {
+	return func(benchmarker *Benchmarker) {
+		benchmarker.customizer = customizer
+	}
+}
+
+// 能够提供分数计算次数的分数指导器
+type calculationCounter interface {
+	GetCalculationCount() int64
+}
+
+// Run 依次运行所有基准测试并生成报告
+// 上下文取消时停止运行，返回已完成运行的报告与上下文的错误
+func (b *Benchmarker) Run(ctx context.Context) (*BenchmarkReport, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	report := &BenchmarkReport{
+		StartTime:   time.Now(),
+		RepeatCount: b.repeatCount,
+	}
+	for _, dataset := range b.datasets {
+		report.Datasets = append(report.Datasets, dataset.Name)
+	}
+	var runErr error
+	for _, sb := range b.solvers {
+		solverResult := &SolverResult{Name: sb.Name}
+		for _, dataset := range b.datasets {
+			datasetResult := &DatasetResult{Dataset: dataset.Name}
+			for i := 0; i < b.repeatCount && runErr == nil; i++ {
+				run, err := b.runOnce(ctx, sb, dataset, i)
+				if err != nil {
+					runErr = err
+					break
+				}
+				datasetResult.Runs = append(datasetResult.Runs, run)
+			}
+			datasetResult.summarize()
+			solverResult.Datasets = append(solverResult.Datasets, datasetResult)
+		}
+		report.Solvers = append(report.Solvers, solverResult)
+	}
+	report.DurationMillis = time.Since(report.StartTime).Milliseconds()
+	report.rank()
+	return report, runErr
+}
+
+func (b *Benchmarker) validate() error {
+	if b.newScoreDirector == nil {
+		return errors.New("benchmark: score director factory is required")
+	}
+	if len(b.solvers) == 0 {
+		return errors.New("benchmark: at least one solver is required")
+	}
+	if len(b.datasets) == 0 {
+		return errors.New("benchmark: at least one dataset is required")
+	}
+	if b.repeatCount < 1 {
+		return fmt.Errorf("benchmark: repeat count must be at least 1, got %d", b.repeatCount)
+	}
+	names := make(map[string]bool)
+	for _, sb := range b.solvers {
+		if names[sb.Name] {
+			return fmt.Errorf("benchmark: duplicate solver name %q", sb.Name)
+		}
+		names[sb.Name] = true
+		if err := sb.Config.Validate(); err != nil {
+			return fmt.Errorf("benchmark: solver %q: %w", sb.Name, err)
+		}
+	}
+	return nil
+}
+
+// runOnce 以第 index 个随机种子运行一次，求解器返回的错误记录在运行结果中，只有上下文取消时返回错误
+func (b *Benchmarker) runOnce(ctx context.Context, sb SolverBenchmark, dataset Dataset, index int) (*RunResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	cfg := *sb.Config
+	cfg.RandomSeed = sb.Config.RandomSeed + int64(index)
+	scoreDirector := b.newScoreDirector()
+	s, err := solver.NewDefaultSolver(&cfg, scoreDirector)
+	if err != nil {
+		return nil, fmt.Errorf("benchmark: solver %q: %w", sb.Name, err)
+	}
+	if b.customizer != nil {
+		b.customizer(s)
+	}
+
+	run := &RunResult{Seed: cfg.RandomSeed}
+	startTime := time.Now()
+	s.AddBestSolutionChangedListener(func(_ api.ISolution, bestScore api.IScore) {
+		run.bestScore = bestScore
+		run.BestScoreHistory = append(run.BestScoreHistory, ScorePoint{
+			TimeMillis: time.Since(startTime).Milliseconds(),
+			Score:      bestScore.ToShortString(),
+			Levels:     bestScore.ToLevelDoubles(),
+		})
+	})
+	var startCount int64
+	counter, counted := scoreDirector.(calculationCounter)
+	if counted {
+		startCount = counter.GetCalculationCount()
+	}
+	_, solveErr := s.SolveContext(ctx, dataset.NewProblem())
+	timeSpent := time.Since(startTime)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	run.TimeSpentMillis = timeSpent.Milliseconds()
+	run.StepCount = s.GetStepCount()
+	run.MoveCount = s.GetMoveCount()
+	run.TerminationReason = s.GetTerminationReason().String()
+	if counted {
+		run.ScoreCalculationCount = counter.GetCalculationCount() - startCount
+		if seconds := timeSpent.Seconds(); seconds > 0 {
+			run.ScoreCalculationSpeed = float64(run.ScoreCalculationCount) / seconds
+		}
+	}
+	if solveErr != nil {
+		run.Error = solveErr.Error()
+	}
+	if run.bestScore != nil {
+		run.BestScore = run.bestScore.ToShortString()
+	}
+	return run, nil
+}
@@ -0,0 +1,163 @@
+package benchmark
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+	"github.com/kruily/go-timefold-solver/solver/solver"
+)
+
+func newQueensScoreDirector() api.IScoreDirector {
+	return codec.NewScoreDirector(nqueens.Codec{})
+}
+
+func queensConfig(seed int64) *config.SolverConfig {
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	cfg.RandomSeed = seed
+	cfg.MoveSelector = config.MOVE_SELECTOR_RANDOM_CHANGE
+	cfg.Termination = config.TerminationConfig{StepCountLimit: 30, MoveCountLimit: 100}
+	return cfg
+}
+
+func newQueensBenchmarker(options ...BenchmarkerOption) *Benchmarker {
+	options = append([]BenchmarkerOption{
+		WithDataset("4 queens", func() api.ISolution { return nqueens.NewBoard(4) }),
+		WithDataset("6 queens", func() api.ISolution { return nqueens.NewBoard(6) }),
+		WithRepeatCount(2),
+	}, options...)
+	return NewBenchmarker(newQueensScoreDirector, options...)
+}
+
+func TestRunCollectsEveryRun(t *testing.T) {
+	report, err := newQueensBenchmarker(
+		WithSolver("local search", queensConfig(10)),
+		WithSolver("construction only", func() *config.SolverConfig {
+			cfg := queensConfig(20)
+			cfg.LocalSearch = false
+			return cfg
+		}()),
+	).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Solvers) != 2 || len(report.Datasets) != 2 {
+		t.Fatalf("got %d solvers and %d datasets", len(report.Solvers), len(report.Datasets))
+	}
+	for _, s := range report.Solvers {
+		if s.Rank == 0 {
+			t.Errorf("%s was not ranked", s.Name)
+		}
+		for _, d := range s.Datasets {
+			if len(d.Runs) != 2 || d.FailedRunCount != 0 {
+				t.Errorf("%s on %s: got %d runs with %d failures", s.Name, d.Dataset, len(d.Runs), d.FailedRunCount)
+				continue
+			}
+			for i, run := range d.Runs {
+				if run.BestScore == "" || len(run.BestScoreHistory) == 0 || run.TerminationReason == "" {
+					t.Errorf("%s on %s run %d: got %+v", s.Name, d.Dataset, i, run)
+				}
+			}
+		}
+	}
+	// 第 i 次运行的随机种子为 RandomSeed + i
+	for i, want := range []int64{10, 20} {
+		runs := report.Solvers[i].Datasets[1].Runs
+		if len(runs) == 2 && (runs[0].Seed != want || runs[1].Seed != want+1) {
+			t.Errorf("%s: got seeds %d and %d, want %d and %d", report.Solvers[i].Name, runs[0].Seed, runs[1].Seed, want, want+1)
+		}
+	}
+	if report.Winner == "" {
+		t.Errorf("no winner")
+	}
+}
+
+func TestRunRecordsFailedRuns(t *testing.T) {
+	// 分数类型与解决方案不一致，每次求解都在开始前失败
+	mismatched := queensConfig(0)
+	mismatched.Termination.BestScoreLimit = simple.NewSimpleScore(0, 0)
+	report, err := newQueensBenchmarker(
+		WithSolver("mismatched", mismatched),
+		WithSolver("working", queensConfig(0)),
+	).Run(context.Background())
+	if err != nil {
+		t.Fatalf("a failed run stopped the benchmark: %v", err)
+	}
+	failed := report.Solvers[0]
+	for _, d := range failed.Datasets {
+		if d.FailedRunCount != 2 || d.BestScore != "" {
+			t.Errorf("%s: got %d failed runs and best score %q", d.Dataset, d.FailedRunCount, d.BestScore)
+		}
+		for _, run := range d.Runs {
+			if !strings.Contains(run.Error, "score type") {
+				t.Errorf("got run error %q, want the score type mismatch", run.Error)
+			}
+		}
+	}
+	if failed.Rank != 0 || report.Winner != "working" {
+		t.Errorf("got rank %d for the failed solver and winner %q", failed.Rank, report.Winner)
+	}
+}
+
+func TestRunStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	created := 0
+	report, err := newQueensBenchmarker(
+		WithSolver("first", queensConfig(0)),
+		WithSolver("second", queensConfig(0)),
+		// 第二次运行开始前取消
+		WithSolverCustomizer(func(*solver.DefaultSolver) {
+			created++
+			if created == 2 {
+				cancel()
+			}
+		}),
+	).Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if report == nil {
+		t.Fatal("got no report for the completed run")
+	}
+	if runs := report.Solvers[0].Datasets[0].Runs; len(runs) != 1 || runs[0].BestScore == "" {
+		t.Errorf("got runs %+v, want only the completed run", runs)
+	}
+	if len(report.Solvers) != 2 || len(report.Solvers[1].Datasets[0].Runs) != 0 {
+		t.Errorf("runs were started after the cancellation")
+	}
+	if report.Winner != "" || len(report.GetRanking()) != 0 {
+		t.Errorf("got winner %q without complete runs", report.Winner)
+	}
+}
+
+func TestRunValidatesBenchmarks(t *testing.T) {
+	invalid := queensConfig(0)
+	invalid.MoveSelector = "SIDEWAYS"
+	dataset := WithDataset("4 queens", func() api.ISolution { return nqueens.NewBoard(4) })
+	tests := []struct {
+		name       string
+		benchmarks *Benchmarker
+		want       string
+	}{
+		{"no score director", NewBenchmarker(nil, WithSolver("a", queensConfig(0)), dataset), "score director factory is required"},
+		{"no solver", NewBenchmarker(newQueensScoreDirector, dataset), "at least one solver"},
+		{"no dataset", NewBenchmarker(newQueensScoreDirector, WithSolver("a", queensConfig(0))), "at least one dataset"},
+		{"repeat count", NewBenchmarker(newQueensScoreDirector, WithSolver("a", queensConfig(0)), dataset, WithRepeatCount(0)), "repeat count must be at least 1, got 0"},
+		{"duplicate name", NewBenchmarker(newQueensScoreDirector, WithSolver("a", queensConfig(0)), WithSolver("a", queensConfig(1)), dataset), `duplicate solver name "a"`},
+		{"invalid config", NewBenchmarker(newQueensScoreDirector, WithSolver("a", invalid), dataset), `solver "a": MoveSelector has unknown value "SIDEWAYS"`},
+	}
+	for _, tt := range tests {
+		report, err := tt.benchmarks.Run(context.Background())
+		if report != nil || err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got report %v and error %v, want %q", tt.name, report, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/benchmark"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+)
+
+// runBenchmark 在每个问题上比较每个求解器配置，输出排名并生成 HTML 与 JSON 报告
+// 求解器与数据集以文件名（不含扩展名）命名
+func runBenchmark(ctx context.Context, env *environment, args []string) error {
+	flags := env.newFlagSet("benchmark")
+	domainName := flags.String("domain", "", "registered domain codec (optional when only one is registered)")
+	var configPaths, problemPaths stringList
+	flags.Var(&configPaths, "config", "solver config file to compare (repeatable)")
+	flags.Var(&problemPaths, "problem", "problem file to solve (repeatable)")
+	repeatCount := flags.Int("repeat", 1, "number of runs per config and problem, each with a different seed")
+	outputDir := flags.String("output", "benchmark-report", "directory to write index.html and summary.json to")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireFlag(flags, "config", configPaths.String()); err != nil {
+		return err
+	}
+	if err := requireFlag(flags, "problem", problemPaths.String()); err != nil {
+		return err
+	}
+
+	domain, err := codec.Lookup(*domainName)
+	if err != nil {
+		return err
+	}
+	options := []benchmark.BenchmarkerOption{benchmark.WithRepeatCount(*repeatCount)}
+	for _, path := range configPaths {
+		cfg, err := loadConfig(path, domain)
+		if err != nil {
+			return err
+		}
+		options = append(options, benchmark.WithSolver(baseName(path), cfg))
+	}
+	for _, path := range problemPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read problem: %w", err)
+		}
+		// 先解码一次以便尽早报告格式错误
+		if _, err := domain.DecodeProblem(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("decode problem %s: %w", path, err)
+		}
+		options = append(options, benchmark.WithDataset(baseName(path), func() api.ISolution {
+			problem, _ := domain.DecodeProblem(bytes.NewReader(data))
+			return problem
+		}))
+	}
+
+	benchmarker := benchmark.NewBenchmarker(func() api.IScoreDirector {
+		return codec.NewScoreDirector(domain)
+	}, options...)
+	report, runErr := benchmarker.Run(ctx)
+	if report == nil {
+		return runErr
+	}
+	if err := report.WriteFiles(*outputDir); err != nil {
+		return err
+	}
+	for _, s := range report.GetRanking() {
+		fmt.Fprintf(env.stdout, "%d. %s  total %s, won %d of %d datasets\n", s.Rank, s.Name, s.TotalScore, s.WinCount, len(report.Datasets))
+	}
+	fmt.Fprintf(env.stdout, "report written to %s\n", filepath.Join(*outputDir, benchmark.HTMLReportFileName))
+	return runErr
+}
+
+// baseName 获取不含目录与扩展名的文件名
+func baseName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+// 退出码
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// 子命令，run 返回的错误输出到标准错误
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *environment, args []string) error
+}
+
+var commands = []command{
+	{name: "solve", summary: "solve a problem and write the best solution", run: runSolve},
+	{name: "benchmark", summary: "compare solver configs on several problems and write a report", run: runBenchmark},
+	{name: "validate-config", summary: "check solver config files", run: runValidateConfig},
+	{name: "explain", summary: "print the score breakdown of a solution", run: runExplain},
+}
+
+// 命令的输入输出
+type environment struct {
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// 参数错误，退出码为 ExitUsage
+var errUsage = errors.New("usage error")
+
+// Run 运行命令行工具，返回退出码
+// 领域编解码器需要在调用前通过 codec.Register 注册，上下文取消时（如收到中断信号）求解停止并输出目前的最佳解决方案
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	env := &environment{stdout: stdout, stderr: stderr}
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		env.usage()
+		if len(args) == 0 {
+			return ExitUsage
+		}
+		return ExitOK
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(ctx, env, args[1:])
+		switch {
+		case err == nil:
+			return ExitOK
+		case errors.Is(err, flag.ErrHelp):
+			return ExitOK
+		case errors.Is(err, errUsage):
+			return ExitUsage
+		default:
+			fmt.Fprintf(stderr, "%s: %v\n", cmd.name, err)
+			return ExitError
+		}
+	}
+	fmt.Fprintf(stderr, "unknown command %q\n", args[0])
+	env.usage()
+	return ExitUsage
+}
+
+func (env *environment) usage() {
+	fmt.Fprintln(env.stderr, "Usage: timefold <command> [flags]")
+	fmt.Fprintln(env.stderr)
+	fmt.Fprintln(env.stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(env.stderr, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(env.stderr)
+	fmt.Fprintf(env.stderr, "Registered domains: %s\n", domainList())
+	fmt.Fprintln(env.stderr, "Run 'timefold <command> -h' for the flags of a command.")
+}
+
+// newFlagSet 创建子命令的参数解析，解析错误只输出用法
+func (env *environment) newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(env.stderr)
+	return flags
+}
+
+// parseFlags 解析参数，参数错误时返回 errUsage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		flags.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// requireFlag 检查必填参数
+func requireFlag(flags *flag.FlagSet, name, value string) error {
+	if value != "" {
+		return nil
+	}
+	fmt.Fprintf(flags.Output(), "flag -%s is required\n", name)
+	flags.Usage()
+	return errUsage
+}
+
+func domainList() string {
+	names := codec.Names()
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
+
+// 可重复的字符串参数
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// loadConfig 加载求解器配置，路径为空时使用默认配置
+func loadConfig(path string, domain codec.DomainCodec) (*config.SolverConfig, error) {
+	if path == "" {
+		return config.NewDefalutSolverConfig(), nil
+	}
+	var options []config.LoaderOption
+	if domain != nil {
+		options = codec.LoaderOptions(domain)
+	}
+	return config.LoadSolverConfig(path, options...)
+}
+
+// readProblem 读取并解码问题文件
+func readProblem(domain codec.DomainCodec, path string) (api.ISolution, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open problem: %w", err)
+	}
+	defer file.Close()
+	problem, err := domain.DecodeProblem(file)
+	if err != nil {
+		return nil, fmt.Errorf("decode problem %s: %w", path, err)
+	}
+	return problem, nil
+}
+
+// writeSolution 编码解决方案，路径为空或为 "-" 时输出到标准输出
+func (env *environment) writeSolution(domain codec.DomainCodec, path string, solution api.ISolution) error {
+	if path == "" || path == "-" {
+		if err := domain.EncodeSolution(env.stdout, solution); err != nil {
+			return fmt.Errorf("encode solution: %w", err)
+		}
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+	if err := domain.EncodeSolution(file, solution); err != nil {
+		file.Close()
+		return fmt.Errorf("encode solution: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close output: %w", err)
+	}
+	return nil
+}
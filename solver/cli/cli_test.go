@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/benchmark"
+)
+
+// 求解很快结束的配置
+const quickConfig = `
+time_limit: 5s
+termination:
+  step_count_limit: 20
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = Run(context.Background(), args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRunWithoutArgumentsPrintsUsage(t *testing.T) {
+	code, _, stderr := run()
+	if code != ExitUsage {
+		t.Errorf("got exit code %d, want %d", code, ExitUsage)
+	}
+	for _, want := range []string{"Usage: timefold", "solve", "validate-config", "Registered domains: nqueens"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("usage does not contain %q:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	if code, _, _ := run("-h"); code != ExitOK {
+		t.Errorf("got exit code %d, want %d", code, ExitOK)
+	}
+	if code, _, _ := run("solve", "-h"); code != ExitOK {
+		t.Errorf("solve -h: got exit code %d, want %d", code, ExitOK)
+	}
+}
+
+func TestRunUnknownCommand(t *testing.T) {
+	code, _, stderr := run("optimize")
+	if code != ExitUsage {
+		t.Errorf("got exit code %d, want %d", code, ExitUsage)
+	}
+	if !strings.Contains(stderr, `unknown command "optimize"`) {
+		t.Errorf("stderr does not report the unknown command:\n%s", stderr)
+	}
+}
+
+func TestRunSolveRequiresProblem(t *testing.T) {
+	code, _, stderr := run("solve")
+	if code != ExitUsage {
+		t.Errorf("got exit code %d, want %d", code, ExitUsage)
+	}
+	if !strings.Contains(stderr, "flag -problem is required") {
+		t.Errorf("stderr does not report the missing flag:\n%s", stderr)
+	}
+}
+
+func TestRunSolveWritesSolution(t *testing.T) {
+	problem := writeFile(t, "problem.json", `{"n": 6}`)
+	cfg := writeFile(t, "config.yaml", quickConfig)
+	output := filepath.Join(t.TempDir(), "solution.json")
+
+	code, _, stderr := run("solve", "-problem", problem, "-config", cfg, "-output", output, "-quiet")
+	if code != ExitOK {
+		t.Fatalf("got exit code %d, want %d, stderr:\n%s", code, ExitOK, stderr)
+	}
+	if !strings.Contains(stderr, "Score: HardSoftScore[initScore=0") {
+		t.Errorf("stderr does not contain the score breakdown of an initialized solution:\n%s", stderr)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var solution struct {
+		N     int    `json:"n"`
+		Rows  []*int `json:"rows"`
+		Score string `json:"score"`
+	}
+	if err := json.Unmarshal(data, &solution); err != nil {
+		t.Fatalf("decode solution: %v\n%s", err, data)
+	}
+	if solution.N != 6 || len(solution.Rows) != 6 {
+		t.Fatalf("got n %d with %d rows, want 6 and 6", solution.N, len(solution.Rows))
+	}
+	for column, row := range solution.Rows {
+		if row == nil {
+			t.Errorf("queen of column %d is not placed", column)
+		}
+	}
+	if solution.Score == "" {
+		t.Error("solution has no score")
+	}
+}
+
+func TestRunSolveReportsInvalidProblem(t *testing.T) {
+	problem := writeFile(t, "problem.json", `{"n": 0}`)
+	code, _, stderr := run("solve", "-problem", problem, "-quiet")
+	if code != ExitError {
+		t.Errorf("got exit code %d, want %d", code, ExitError)
+	}
+	if !strings.Contains(stderr, "n must be at least 1") {
+		t.Errorf("stderr does not report the decode error:\n%s", stderr)
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	// 第 0、1 列在同一行，第 1、2 列与第 0、3 列在同一条上升对角线上
+	solution := writeFile(t, "solution.json", `{"n": 4, "rows": [0, 0, 1, 3]}`)
+
+	code, stdout, stderr := run("explain", "-solution", solution)
+	if code != ExitOK {
+		t.Fatalf("got exit code %d, want %d, stderr:\n%s", code, ExitOK, stderr)
+	}
+	for _, want := range []string{
+		"Score: HardSoftScore[initScore=0, hardScore=-3, softScore=0]",
+		"row conflict (weight -1): matched 1 time(s), match weight 1",
+		"ascending diagonal conflict (weight -1): matched 1 time(s), match weight 2",
+		"descending diagonal conflict (weight -1): not matched",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("explanation does not contain %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRunExplainJSON(t *testing.T) {
+	solution := writeFile(t, "solution.json", `{"n": 4, "rows": [0, 0, 1, 3]}`)
+
+	code, stdout, stderr := run("explain", "-solution", solution, "-json")
+	if code != ExitOK {
+		t.Fatalf("got exit code %d, want %d, stderr:\n%s", code, ExitOK, stderr)
+	}
+	var explanation struct {
+		Score       string `json:"score"`
+		Constraints []struct {
+			Name    string `json:"name"`
+			Matched bool   `json:"matched"`
+		} `json:"constraints"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &explanation); err != nil {
+		t.Fatalf("decode explanation: %v\n%s", err, stdout)
+	}
+	if explanation.Score != "HardSoftScore[initScore=0, hardScore=-3, softScore=0]" {
+		t.Errorf("got score %s", explanation.Score)
+	}
+	if len(explanation.Constraints) != 3 {
+		t.Errorf("got %d constraints, want 3", len(explanation.Constraints))
+	}
+}
+
+func TestRunValidateConfig(t *testing.T) {
+	valid := writeFile(t, "valid.yaml", quickConfig)
+	invalid := writeFile(t, "invalid.yaml", "move_selector: SIDEWAYS\n")
+
+	code, stdout, _ := run("validate-config", valid)
+	if code != ExitOK {
+		t.Errorf("valid config: got exit code %d, want %d", code, ExitOK)
+	}
+	if !strings.Contains(stdout, valid+": ok") {
+		t.Errorf("stdout does not report the valid config:\n%s", stdout)
+	}
+
+	code, stdout, stderr := run("validate-config", "-config", valid, invalid)
+	if code != ExitError {
+		t.Errorf("invalid config: got exit code %d, want %d", code, ExitError)
+	}
+	if !strings.Contains(stdout, invalid+": ") || strings.Contains(stdout, invalid+": ok") {
+		t.Errorf("stdout does not report the invalid config:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "1 of 2 config files are invalid") {
+		t.Errorf("stderr does not summarize the invalid configs:\n%s", stderr)
+	}
+}
+
+func TestRunBenchmarkWritesReport(t *testing.T) {
+	cfg := writeFile(t, "quick.yaml", quickConfig)
+	problem := writeFile(t, "six.json", `{"n": 6}`)
+	output := t.TempDir()
+
+	code, stdout, stderr := run("benchmark", "-config", cfg, "-problem", problem, "-output", output)
+	if code != ExitOK {
+		t.Fatalf("got exit code %d, want %d, stderr:\n%s", code, ExitOK, stderr)
+	}
+	if !strings.Contains(stdout, "1. quick") {
+		t.Errorf("stdout does not rank the solver:\n%s", stdout)
+	}
+	for _, name := range []string{benchmark.HTMLReportFileName, benchmark.JSONReportFileName} {
+		if _, err := os.Stat(filepath.Join(output, name)); err != nil {
+			t.Errorf("report file %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+)
+
+// runExplain 输出解决方案的分数明细，不进行求解
+func runExplain(_ context.Context, env *environment, args []string) error {
+	flags := env.newFlagSet("explain")
+	domainName := flags.String("domain", "", "registered domain codec (optional when only one is registered)")
+	solutionPath := flags.String("solution", "", "solution file to explain")
+	asJSON := flags.Bool("json", false, "print the breakdown as JSON, including constraint justifications")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireFlag(flags, "solution", *solutionPath); err != nil {
+		return err
+	}
+
+	domain, err := codec.Lookup(*domainName)
+	if err != nil {
+		return err
+	}
+	solution, err := readProblem(domain, *solutionPath)
+	if err != nil {
+		return err
+	}
+	explanation, err := score.ExplainScore(constraint.ForSolution(domain.ConstraintConfigure(), solution), solution)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return explanation.WriteJSON(env.stdout)
+	}
+	fmt.Fprint(env.stdout, explanation.Summary())
+	return nil
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solver"
+)
+
+// runSolve 求解问题，进度与分数明细输出到标准错误，解决方案输出到 -output
+func runSolve(ctx context.Context, env *environment, args []string) error {
+	flags := env.newFlagSet("solve")
+	domainName := flags.String("domain", "", "registered domain codec (optional when only one is registered)")
+	problemPath := flags.String("problem", "", "problem file to solve")
+	configPath := flags.String("config", "", "solver config file (YAML or JSON), defaults to the built-in config")
+	outputPath := flags.String("output", "-", "file to write the best solution to, - for stdout")
+	quiet := flags.Bool("quiet", false, "do not print progress")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireFlag(flags, "problem", *problemPath); err != nil {
+		return err
+	}
+
+	domain, err := codec.Lookup(*domainName)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*configPath, domain)
+	if err != nil {
+		return err
+	}
+	problem, err := readProblem(domain, *problemPath)
+	if err != nil {
+		return err
+	}
+	s, err := solver.NewDefaultSolver(cfg, codec.NewScoreDirector(domain))
+	if err != nil {
+		return err
+	}
+	startTime := time.Now()
+	if !*quiet {
+		s.AddBestSolutionChangedListener(func(_ api.ISolution, bestScore api.IScore) {
+			fmt.Fprintf(env.stderr, "[%8.3fs] new best score %s\n", time.Since(startTime).Seconds(), bestScore.ToShortString())
+		})
+	}
+
+	best, solveErr := s.SolveContext(ctx, problem)
+	if solveErr != nil && !errors.Is(solveErr, context.Canceled) {
+		// 分数损坏等错误仍然输出目前的最佳解决方案，便于排查
+		fmt.Fprintf(env.stderr, "solve failed: %v\n", solveErr)
+	}
+	if !*quiet {
+		fmt.Fprintf(env.stderr, "solving ended after %.3fs: %s, %d steps, %d moves\n",
+			time.Since(startTime).Seconds(), s.GetTerminationReason(), s.GetStepCount(), s.GetMoveCount())
+	}
+	if best == nil {
+		return fmt.Errorf("no solution found: %w", solveErr)
+	}
+	if err := env.writeSolution(domain, *outputPath, best); err != nil {
+		return err
+	}
+	explanation, err := score.ExplainScore(constraint.ForSolution(domain.ConstraintConfigure(), best), best)
+	if err != nil {
+		return fmt.Errorf("explain score: %w", err)
+	}
+	fmt.Fprint(env.stderr, explanation.Summary())
+	if solveErr != nil && !errors.Is(solveErr, context.Canceled) {
+		return solveErr
+	}
+	return nil
+}
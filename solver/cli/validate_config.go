@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/codec"
+)
+
+// runValidateConfig 检查求解器配置文件，文件通过 -config 或位置参数给出
+func runValidateConfig(_ context.Context, env *environment, args []string) error {
+	flags := env.newFlagSet("validate-config")
+	domainName := flags.String("domain", "", "registered domain codec used to parse scores in the config (optional)")
+	var paths stringList
+	flags.Var(&paths, "config", "solver config file to check (repeatable, files may also be given as arguments)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	paths = append(paths, flags.Args()...)
+	if len(paths) == 0 {
+		fmt.Fprintln(env.stderr, "at least one config file is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	var domain codec.DomainCodec
+	if *domainName != "" {
+		var err error
+		if domain, err = codec.Lookup(*domainName); err != nil {
+			return err
+		}
+	}
+	invalid := 0
+	for _, path := range paths {
+		if _, err := loadConfig(path, domain); err != nil {
+			invalid++
+			fmt.Fprintf(env.stdout, "%s: %v\n", path, err)
+			continue
+		}
+		fmt.Fprintf(env.stdout, "%s: ok\n", path)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d config files are invalid", invalid, len(paths))
+	}
+	return nil
+}
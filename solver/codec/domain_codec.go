@@ -0,0 +1,89 @@
+package codec
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// DomainCodec 领域编解码器，命令行工具通过它读取问题、输出解决方案并获取领域的约束
+// 领域包在 init 中调用 Register 注册，命令行程序以空白导入引入领域包
+type DomainCodec interface {
+	// DecodeProblem 读取问题
+	DecodeProblem(r io.Reader) (api.ISolution, error)
+	// EncodeSolution 输出解决方案
+	EncodeSolution(w io.Writer, solution api.ISolution) error
+	// ConstraintConfigure 获取领域的约束
+	ConstraintConfigure() api.IConstraintConfigure
+}
+
+// ScoreDirectorFactory 可选接口，领域需要自定义分数指导器（如增量计算）时实现
+type ScoreDirectorFactory interface {
+	NewScoreDirector() api.IScoreDirector
+}
+
+// ScoreParser 可选接口，领域的分数不是 HardSoftScore 时实现，用于解析配置中的分数
+type ScoreParser interface {
+	ParseScore(score string) (api.IScore, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = make(map[string]DomainCodec)
+)
+
+// Register 以名称注册领域编解码器，名称重复或编解码器为 nil 时 panic
+func Register(name string, codec DomainCodec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	if codec == nil {
+		panic("codec: Register codec is nil")
+	}
+	if _, ok := codecs[name]; ok {
+		panic("codec: Register called twice for domain " + name)
+	}
+	codecs[name] = codec
+}
+
+// Lookup 按名称查找领域编解码器
+// 名称为空且只注册了一个编解码器时返回该编解码器
+func Lookup(name string) (DomainCodec, error) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	if name == "" {
+		if len(codecs) == 1 {
+			for _, codec := range codecs {
+				return codec, nil
+			}
+		}
+		if len(codecs) == 0 {
+			return nil, fmt.Errorf("no domain codec registered")
+		}
+		return nil, fmt.Errorf("domain is required, registered domains: %s", strings.Join(namesLocked(), ", "))
+	}
+	codec, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown domain %q, registered domains: %s", name, strings.Join(namesLocked(), ", "))
+	}
+	return codec, nil
+}
+
+// Names 获取已注册的领域名称，按字母顺序排列
+func Names() []string {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(codecs))
+	for name := range codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
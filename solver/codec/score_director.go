@@ -0,0 +1,24 @@
+package codec
+
+import (
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/score"
+)
+
+// NewScoreDirector 创建领域的分数指导器，领域未实现 ScoreDirectorFactory 时从约束创建
+func NewScoreDirector(codec DomainCodec) api.IScoreDirector {
+	if factory, ok := codec.(ScoreDirectorFactory); ok {
+		return factory.NewScoreDirector()
+	}
+	constraintManager := codec.ConstraintConfigure()
+	return score.NewScoreDirector(score.NewScoreCalculator(constraintManager), constraintManager)
+}
+
+// LoaderOptions 获取加载求解器配置时领域需要的选项
+func LoaderOptions(codec DomainCodec) []config.LoaderOption {
+	if parser, ok := codec.(ScoreParser); ok {
+		return []config.LoaderOption{config.WithScoreParser(parser.ParseScore)}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+const (
+	// 构造启发式类型
+	ConstructionHeuristicTypeFirstFit                 = "FIRST_FIT"
+	ConstructionHeuristicTypeFirstFitDecreasing       = "FIRST_FIT_DECREASING"
+	ConstructionHeuristicTypeWeakestFit               = "WEAKEST_FIT"
+	ConstructionHeuristicTypeWeakestFitDecreasing     = "WEAKEST_FIT_DECREASING"
+	ConstructionHeuristicTypeStrongestFit             = "STRONGEST_FIT"
+	ConstructionHeuristicTypeStrongestFitDecreasing   = "STRONGEST_FIT_DECREASING"
+	ConstructionHeuristicTypeAllocateEntityFromQueue  = "ALLOCATE_ENTITY_FROM_QUEUE"
+	ConstructionHeuristicTypeAllocateToValueFromQueue = "ALLOCATE_TO_VALUE_FROM_QUEUE"
+	ConstructionHeuristicTypeCheapestInsertion        = "CHEAPEST_INSERTION"
+)
+
+const (
+	// 提前选取类型
+	PickEarlyTypeNever                      = "NEVER"
+	PickEarlyTypeFirstNonDeterioratingScore = "FIRST_NON_DETERIORATING_SCORE"
+	PickEarlyTypeFirstFeasibleScore         = "FIRST_FEASIBLE_SCORE"
+)
+
+const (
+	// 实体排序方式
+	EntitySorterMannerNone                 = "NONE"
+	EntitySorterMannerDecreasingDifficulty = "DECREASING_DIFFICULTY"
+)
+
+const (
+	// 值排序方式
+	ValueSorterMannerNone               = "NONE"
+	ValueSorterMannerIncreasingStrength = "INCREASING_STRENGTH"
+	ValueSorterMannerDecreasingStrength = "DECREASING_STRENGTH"
+)
+
+type ConstructionHeuristicConfig struct {
+	// 构造启发式类型
+	Type string // "FIRST_FIT", "FIRST_FIT_DECREASING", "WEAKEST_FIT", ...
+	// 实体排序方式，仅用于 ALLOCATE_* 与 CHEAPEST_INSERTION，其余类型由类型决定
+	EntitySorterManner string
+	// 值排序方式，仅用于 ALLOCATE_* 与 CHEAPEST_INSERTION，其余类型由类型决定
+	ValueSorterManner string
+	// 提前选取类型，默认评估所有值后选取最佳值
+	PickEarlyType string // "NEVER", "FIRST_NON_DETERIORATING_SCORE", "FIRST_FEASIBLE_SCORE"
+	// 终止配置
+	Termination TerminationConfig
+}
@@ -0,0 +1,31 @@
+package config
+
+const (
+	// 穷举搜索类型
+	ExhaustiveSearchTypeBruteForce     = "BRUTE_FORCE"
+	ExhaustiveSearchTypeBranchAndBound = "BRANCH_AND_BOUND"
+)
+
+const (
+	// 节点探索方式
+	NodeExplorationTypeDepthFirst           = "DEPTH_FIRST"
+	NodeExplorationTypeBreadthFirst         = "BREADTH_FIRST"
+	NodeExplorationTypeScoreFirst           = "SCORE_FIRST"
+	NodeExplorationTypeOptimisticBoundFirst = "OPTIMISTIC_BOUND_FIRST"
+)
+
+type ExhaustiveSearchConfig struct {
+	// 穷举搜索类型
+	Type string // "BRUTE_FORCE", "BRANCH_AND_BOUND"
+	// 节点探索方式，仅用于 BRANCH_AND_BOUND，默认深度优先
+	NodeExplorationType string // "DEPTH_FIRST", "BREADTH_FIRST", "SCORE_FIRST", "OPTIMISTIC_BOUND_FIRST"
+	// 实体排序方式
+	EntitySorterManner string
+	// 值排序方式
+	ValueSorterManner string
+	// 分数指导器未提供乐观边界时，假设分数只会随着赋值变差（只有惩罚约束），以当前分数作为乐观边界剪枝
+	// 未启用且没有乐观边界时分支定界不剪枝，有奖励约束时不能启用
+	AssumeScoreOnlyWorsens bool
+	// 阶段终止配置
+	Termination TerminationConfig
+}
@@ -4,14 +4,11 @@ const (
 	// 局部搜索类型
 	LocalSearchTypeSimulatedAnnealing = "SIMULATED_ANNEALING"
 	LocalSearchTypeTabuSearch         = "TABU_SEARCH"
-	LocalSearchTypeLateAcceptance     = "LATE_ACCEPTANCE"
 )
 
 type LocalSearchConfig struct {
 	// 局部搜索类型
-	Type string // "SIMULATED_ANNEALING", "TABU_SEARCH", "LATE_ACCEPTANCE"
-	// 接受类型
-	AcceptorType string
+	Type string // "SIMULATED_ANNEALING", "TABU_SEARCH"
 	// 禁忌步长
 	TabuSize int
 	// 模拟退火初始温度
@@ -20,4 +17,6 @@ type LocalSearchConfig struct {
 	CoolingRate float64
 	// 禁忌搜索配置
 	TabuSearchConfig TabuSearchConfig
+	// 阶段终止配置
+	Termination TerminationConfig
 }
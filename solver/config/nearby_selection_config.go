@@ -0,0 +1,25 @@
+package config
+
+const (
+	// 就近选择的概率分布
+	NearbyDistributionBlock     = "BLOCK"
+	NearbyDistributionLinear    = "LINEAR"
+	NearbyDistributionParabolic = "PARABOLIC"
+	NearbyDistributionBeta      = "BETA"
+)
+
+type NearbySelectionConfig struct {
+	// 概率分布类型，默认 LINEAR
+	DistributionType string // "BLOCK", "LINEAR", "PARABOLIC", "BETA"
+	// BLOCK：块的最小、最大大小与占所有终点的比例，以及在所有终点中均匀选择的概率
+	BlockSizeMinimum                    int
+	BlockSizeMaximum                    int
+	BlockSizeRatio                      float64
+	BlockUniformDistributionProbability float64
+	// LINEAR 与 PARABOLIC：只选择最近的若干终点，0 表示不限制
+	LinearSizeMaximum    int
+	ParabolicSizeMaximum int
+	// BETA：分布参数
+	BetaAlpha float64
+	BetaBeta  float64
+}
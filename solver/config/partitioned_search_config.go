@@ -0,0 +1,8 @@
+package config
+
+type PartitionedSearchConfig struct {
+	// 同时求解的分区数上限，0 表示不限制
+	RunnablePartThreadLimit int
+	// 每个分区的终止配置，未配置时使用 SolverConfig.Termination
+	Termination TerminationConfig
+}
@@ -0,0 +1,17 @@
+package config
+
+const (
+	// 子柱类型
+	SubPillarTypeNone     = "NONE"
+	SubPillarTypeAll      = "ALL"
+	SubPillarTypeSequence = "SEQUENCE"
+)
+
+type PillarSelectorConfig struct {
+	// 子柱类型，NONE 移动整个柱，ALL 移动柱中任意实体的组合，SEQUENCE 移动柱中连续的实体
+	SubPillarType string // "NONE", "ALL", "SEQUENCE"
+	// 子柱的最少实体数，小于该值的柱不会被选中
+	MinimumSubPillarSize int
+	// 子柱的最多实体数，0 表示不限制
+	MaximumSubPillarSize int
+}
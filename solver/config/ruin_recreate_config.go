@@ -0,0 +1,25 @@
+package config
+
+const (
+	// 默认破坏的实体数范围
+	DefaultMinimumRuinedCount = 5
+	DefaultMaximumRuinedCount = 20
+)
+
+type RuinRecreateConfig struct {
+	// 每次破坏的最少实体数
+	MinimumRuinedCount int
+	// 每次破坏的最多实体数
+	MaximumRuinedCount int
+	// 每次破坏的最少实体比例（0-1），配置比例时忽略实体数
+	MinimumRuinedPercentage float64
+	// 每次破坏的最多实体比例（0-1）
+	MaximumRuinedPercentage float64
+	// 重建使用的构造启发式配置，未配置类型时使用 FIRST_FIT
+	ConstructionHeuristicConfig ConstructionHeuristicConfig
+}
+
+// IsPercentageBased 是否按比例确定破坏的实体数
+func (c *RuinRecreateConfig) IsPercentageBased() bool {
+	return c.MinimumRuinedPercentage > 0 || c.MaximumRuinedPercentage > 0
+}
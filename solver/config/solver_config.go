@@ -1,16 +1,32 @@
 package config
 
-import "time"
-
 const (
 	MOVE_SELECTOR_FIRST_FIT = "FIRST_FIT"
 	MOVE_SELECTOR_BEST_FIT  = "BEST_FIT"
 	MOVE_SELECTOR_CHANGE    = "CHANGE"
 	MOVE_SELECTOR_CHAINED   = "CHAINED"
 	MOVE_SELECTOR_RANDOM    = "RANDOM"
+	// 破坏并重建：取消随机一组实体的赋值，再由构造启发式重新赋值
+	MOVE_SELECTOR_RUIN_RECREATE = "RUIN_RECREATE"
+	// 柱移动：同时移动共享同一规划值的一组实体
+	MOVE_SELECTOR_PILLAR_CHANGE = "PILLAR_CHANGE"
+	MOVE_SELECTOR_PILLAR_SWAP   = "PILLAR_SWAP"
+	// 随机选择实体并随机变更其值
+	MOVE_SELECTOR_RANDOM_CHANGE = "RANDOM_CHANGE"
+)
+
+const (
+	// 环境模式
+	EnvironmentModeReproducible    = "REPRODUCIBLE"
+	EnvironmentModeNonReproducible = "NON_REPRODUCIBLE"
+	EnvironmentModeFastAssert      = "FAST_ASSERT"
+	EnvironmentModeStepAssert      = "STEP_ASSERT"
+	EnvironmentModeFullAssert      = "FULL_ASSERT"
 )
 
 type SolverConfig struct {
+	// 环境模式，断言模式会从头计算分数以检测分数损坏
+	EnvironmentMode string // "REPRODUCIBLE", "NON_REPRODUCIBLE", "FAST_ASSERT", "STEP_ASSERT", "FULL_ASSERT"
 	// 求解时间限制（秒）
 	TimeLimit int
 	// 是否使用并行求解
@@ -24,17 +40,35 @@ type SolverConfig struct {
 	// 终止配置
 	Termination TerminationConfig
 	// 构造启发式配置
-	ConstructionHeuristic string
+	ConstructionHeuristicConfig ConstructionHeuristicConfig
 	// 局部搜索配置
 	LocalSearchConfig LocalSearchConfig
+	// 破坏并重建移动配置，用于 RUIN_RECREATE 移动选择策略
+	RuinRecreateConfig RuinRecreateConfig
+	// 柱选择器配置，用于 PILLAR_CHANGE 与 PILLAR_SWAP 移动选择策略
+	PillarSelectorConfig PillarSelectorConfig
+	// 是否启用就近选择，需要为移动选择器设置 NearbyDistanceMeter
+	// 启用时 RANDOM 与 RANDOM_CHANGE 按距离加权选择目标实体或目标值
+	NearbySelection bool
+	// 就近选择配置
+	NearbySelectionConfig NearbySelectionConfig
+	// 是否启用穷举搜索，启用时以穷举搜索代替构造启发式与局部搜索
+	ExhaustiveSearch bool
+	// 穷举搜索配置
+	ExhaustiveSearchConfig ExhaustiveSearchConfig
+	// 是否启用分区搜索，启用时每个分区按上述阶段配置独立求解
+	PartitionedSearch bool
+	// 分区搜索配置
+	PartitionedSearchConfig PartitionedSearchConfig
 	// 是否启用邻域缓存
 	NeighborhoodCaching bool
-	// 随机种子
+	// 随机种子，求解器的所有随机选择都由此派生
 	RandomSeed int64
 }
 
 func NewDefalutSolverConfig() *SolverConfig {
 	return &SolverConfig{
+		EnvironmentMode:     EnvironmentModeReproducible,
 		TimeLimit:           60,
 		Parallel:            true,
 		ParallelThreadCount: 4,
@@ -44,14 +78,25 @@ func NewDefalutSolverConfig() *SolverConfig {
 			TimeLimit:                300,
 			UnimprovedStepCountLimit: 100,
 		},
-		ConstructionHeuristic: "FIRST_FIT_DECREASING",
+		ConstructionHeuristicConfig: ConstructionHeuristicConfig{
+			Type:          ConstructionHeuristicTypeFirstFitDecreasing,
+			PickEarlyType: PickEarlyTypeNever,
+		},
 		LocalSearchConfig: LocalSearchConfig{
 			Type:               LocalSearchTypeSimulatedAnnealing,
-			AcceptorType:       LocalSearchTypeSimulatedAnnealing,
 			InitialTemperature: 1000,
 			CoolingRate:        0.99,
 		},
+		RuinRecreateConfig: RuinRecreateConfig{
+			MinimumRuinedCount: DefaultMinimumRuinedCount,
+			MaximumRuinedCount: DefaultMaximumRuinedCount,
+		},
+		PillarSelectorConfig: PillarSelectorConfig{
+			SubPillarType:        SubPillarTypeAll,
+			MinimumSubPillarSize: 1,
+		},
 		NeighborhoodCaching: true,
-		RandomSeed:          time.Now().UnixNano(),
+		// 默认使用固定种子以保证可复现
+		RandomSeed: 0,
 	}
 }
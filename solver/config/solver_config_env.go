@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 环境变量覆盖项，键为去掉前缀后的环境变量名
+var envOverrides = map[string]func(cfg *SolverConfig, value string, loader *configLoader) error{
+	"ENVIRONMENT_MODE": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		cfg.EnvironmentMode = value
+		return nil
+	},
+	"TIME_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setSeconds(&cfg.TimeLimit, value)
+	},
+	"PARALLEL": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setBool(&cfg.Parallel, value)
+	},
+	"PARALLEL_THREAD_COUNT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setInt(&cfg.ParallelThreadCount, value)
+	},
+	"MOVE_SELECTOR": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		cfg.MoveSelector = value
+		return nil
+	},
+	"LOCAL_SEARCH": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setBool(&cfg.LocalSearch, value)
+	},
+	"NEIGHBORHOOD_CACHING": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setBool(&cfg.NeighborhoodCaching, value)
+	},
+	"RANDOM_SEED": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		seed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		cfg.RandomSeed = seed
+		return nil
+	},
+	"TERMINATION_TIME_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setSeconds(&cfg.Termination.TimeLimit, value)
+	},
+	"TERMINATION_UNIMPROVED_TIME_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setSeconds(&cfg.Termination.UnimprovedTimeLimit, value)
+	},
+	"TERMINATION_STEP_COUNT_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setInt(&cfg.Termination.StepCountLimit, value)
+	},
+	"TERMINATION_UNIMPROVED_STEP_COUNT_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setInt(&cfg.Termination.UnimprovedStepCountLimit, value)
+	},
+	"TERMINATION_BEST_SCORE_LIMIT": func(cfg *SolverConfig, value string, loader *configLoader) error {
+		score, err := loader.scoreParser(value)
+		if err != nil {
+			return err
+		}
+		cfg.Termination.BestScoreLimit = score
+		return nil
+	},
+	"TERMINATION_BEST_SCORE_FEASIBLE": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		return setBool(&cfg.Termination.BestScoreFeasible, value)
+	},
+	"TERMINATION_MOVE_COUNT_LIMIT": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		cfg.Termination.MoveCountLimit = limit
+		return nil
+	},
+	"CONSTRUCTION_HEURISTIC_TYPE": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		cfg.ConstructionHeuristicConfig.Type = value
+		return nil
+	},
+	"LOCAL_SEARCH_TYPE": func(cfg *SolverConfig, value string, _ *configLoader) error {
+		cfg.LocalSearchConfig.Type = value
+		return nil
+	},
+}
+
+// applyEnv 使用环境变量覆盖配置，如 TIMEFOLD_TIME_LIMIT=30s、TIMEFOLD_TERMINATION_BEST_SCORE_LIMIT=0hard/0soft
+func (l *configLoader) applyEnv(cfg *SolverConfig) error {
+	if l.lookupEnv == nil {
+		return nil
+	}
+	for key, override := range envOverrides {
+		name := l.envPrefix + key
+		value, ok := l.lookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := override(cfg, strings.TrimSpace(value), l); err != nil {
+			return fmt.Errorf("environment variable %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setSeconds(target *int, value string) error {
+	duration, err := parseDuration(value)
+	if err != nil {
+		return err
+	}
+	*target = toSeconds(duration)
+	return nil
+}
+
+func setInt(target *int, value string) error {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid integer %q", value)
+	}
+	*target = v
+	return nil
+}
+
+func setBool(target *bool, value string) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %q", value)
+	}
+	*target = v
+	return nil
+}
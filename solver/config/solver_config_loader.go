@@ -0,0 +1,599 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	hardsoft "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// 配置文件格式
+	ConfigFormatYAML = "YAML"
+	ConfigFormatJSON = "JSON"
+
+	// 默认的环境变量前缀
+	DefaultEnvPrefix = "TIMEFOLD_"
+)
+
+// ScoreParser 分数解析函数，用于解析配置中的分数字符串
+type ScoreParser func(score string) (api.IScore, error)
+
+// LoaderOption 配置加载选项
+type LoaderOption func(*configLoader)
+
+// 配置加载器
+type configLoader struct {
+	scoreParser ScoreParser
+	envPrefix   string
+	lookupEnv   func(key string) (string, bool)
+}
+
+// WithScoreParser 设置分数解析函数，默认解析 HardSoftScore
+func WithScoreParser(parser ScoreParser) LoaderOption {
+	return func(loader *configLoader) {
+		loader.scoreParser = parser
+	}
+}
+
+// WithEnvPrefix 设置环境变量前缀，默认为 "TIMEFOLD_"
+func WithEnvPrefix(prefix string) LoaderOption {
+	return func(loader *configLoader) {
+		loader.envPrefix = prefix
+	}
+}
+
+// WithEnvLookup 设置环境变量查找函数，传入 nil 时不使用环境变量覆盖
+func WithEnvLookup(lookupEnv func(key string) (string, bool)) LoaderOption {
+	return func(loader *configLoader) {
+		loader.lookupEnv = lookupEnv
+	}
+}
+
+func newConfigLoader(options ...LoaderOption) *configLoader {
+	loader := &configLoader{
+		scoreParser: parseHardSoftScore,
+		envPrefix:   DefaultEnvPrefix,
+		lookupEnv:   os.LookupEnv,
+	}
+	for _, option := range options {
+		option(loader)
+	}
+	return loader
+}
+
+func parseHardSoftScore(score string) (api.IScore, error) {
+	return hardsoft.ParseScore(score)
+}
+
+// LoadSolverConfig 从 YAML 或 JSON 文件加载求解器配置，格式由扩展名决定
+// 未配置的字段使用默认配置，随后应用环境变量覆盖
+func LoadSolverConfig(path string, options ...LoaderOption) (*SolverConfig, error) {
+	format, err := formatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read solver config %s: %w", path, err)
+	}
+	cfg, err := ParseSolverConfig(data, format, options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid solver config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// ParseSolverConfig 解析 YAML 或 JSON 格式的求解器配置
+func ParseSolverConfig(data []byte, format string, options ...LoaderOption) (*SolverConfig, error) {
+	loader := newConfigLoader(options...)
+	file := &solverConfigFile{}
+	switch strings.ToUpper(format) {
+	case ConfigFormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+	case ConfigFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format %q, expected %s or %s", format, ConfigFormatYAML, ConfigFormatJSON)
+	}
+
+	cfg := NewDefalutSolverConfig()
+	if err := file.apply(cfg, loader); err != nil {
+		return nil, err
+	}
+	if err := loader.applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	cfg.normalizeEnums()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func formatOf(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return ConfigFormatYAML, nil
+	case ".json":
+		return ConfigFormatJSON, nil
+	default:
+		return "", fmt.Errorf("unsupported solver config file %s, expected .yaml, .yml or .json", path)
+	}
+}
+
+// Duration 配置文件中的时长，支持 "30s"、"1m30s" 等字符串或表示秒数的数字
+type Duration time.Duration
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	return d.parse(node.Value)
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	return d.parse(strings.Trim(string(data), `"`))
+}
+
+func (d *Duration) parse(value string) error {
+	duration, err := parseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// parseDuration 解析时长，纯数字表示秒数
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	duration, err := time.ParseDuration(value)
+	if seconds, intErr := strconv.ParseInt(value, 10, 64); intErr == nil {
+		duration, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected a value like \"30s\" or \"5m\"", value)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("invalid duration %q, must not be negative", value)
+	}
+	return duration, nil
+}
+
+// toSeconds 将时长转换为秒数，不足一秒的部分向上取整
+func toSeconds(duration time.Duration) int {
+	return int((duration + time.Second - 1) / time.Second)
+}
+
+// 配置文件结构，指针字段为 nil 表示未配置
+type solverConfigFile struct {
+	EnvironmentMode       *string                          `yaml:"environment_mode" json:"environment_mode"`
+	TimeLimit             *Duration                        `yaml:"time_limit" json:"time_limit"`
+	Parallel              *bool                            `yaml:"parallel" json:"parallel"`
+	ParallelThreadCount   *int                             `yaml:"parallel_thread_count" json:"parallel_thread_count"`
+	MoveSelector          *string                          `yaml:"move_selector" json:"move_selector"`
+	LocalSearch           *bool                            `yaml:"local_search" json:"local_search"`
+	Termination           *terminationConfigFile           `yaml:"termination" json:"termination"`
+	ConstructionHeuristic *constructionHeuristicConfigFile `yaml:"construction_heuristic" json:"construction_heuristic"`
+	LocalSearchConfig     *localSearchConfigFile           `yaml:"local_search_config" json:"local_search_config"`
+	RuinRecreate          *ruinRecreateConfigFile          `yaml:"ruin_recreate" json:"ruin_recreate"`
+	PillarSelector        *pillarSelectorConfigFile        `yaml:"pillar_selector" json:"pillar_selector"`
+	NearbySelection       *nearbySelectionConfigFile       `yaml:"nearby_selection" json:"nearby_selection"`
+	ExhaustiveSearch      *exhaustiveSearchConfigFile      `yaml:"exhaustive_search" json:"exhaustive_search"`
+	PartitionedSearch     *partitionedSearchConfigFile     `yaml:"partitioned_search" json:"partitioned_search"`
+	NeighborhoodCaching   *bool                            `yaml:"neighborhood_caching" json:"neighborhood_caching"`
+	RandomSeed            *int64                           `yaml:"random_seed" json:"random_seed"`
+}
+
+type terminationConfigFile struct {
+	TimeLimit                *Duration               `yaml:"time_limit" json:"time_limit"`
+	UnimprovedTimeLimit      *Duration               `yaml:"unimproved_time_limit" json:"unimproved_time_limit"`
+	UnimprovedStepCountLimit *int                    `yaml:"unimproved_step_count_limit" json:"unimproved_step_count_limit"`
+	BestScoreLimit           *string                 `yaml:"best_score_limit" json:"best_score_limit"`
+	BestScoreFeasible        *bool                   `yaml:"best_score_feasible" json:"best_score_feasible"`
+	StepCountLimit           *int                    `yaml:"step_count_limit" json:"step_count_limit"`
+	MoveCountLimit           *int64                  `yaml:"move_count_limit" json:"move_count_limit"`
+	CompositionStyle         *string                 `yaml:"composition_style" json:"composition_style"`
+	Terminations             []terminationConfigFile `yaml:"terminations" json:"terminations"`
+}
+
+type constructionHeuristicConfigFile struct {
+	Type               *string                `yaml:"type" json:"type"`
+	PickEarlyType      *string                `yaml:"pick_early_type" json:"pick_early_type"`
+	EntitySorterManner *string                `yaml:"entity_sorter_manner" json:"entity_sorter_manner"`
+	ValueSorterManner  *string                `yaml:"value_sorter_manner" json:"value_sorter_manner"`
+	Termination        *terminationConfigFile `yaml:"termination" json:"termination"`
+}
+
+type localSearchConfigFile struct {
+	Type               *string                `yaml:"type" json:"type"`
+	TabuSize           *int                   `yaml:"tabu_size" json:"tabu_size"`
+	InitialTemperature *float64               `yaml:"initial_temperature" json:"initial_temperature"`
+	CoolingRate        *float64               `yaml:"cooling_rate" json:"cooling_rate"`
+	TabuSearch         *tabuSearchConfigFile  `yaml:"tabu_search" json:"tabu_search"`
+	Termination        *terminationConfigFile `yaml:"termination" json:"termination"`
+}
+
+type ruinRecreateConfigFile struct {
+	MinimumRuinedCount      *int                             `yaml:"minimum_ruined_count" json:"minimum_ruined_count"`
+	MaximumRuinedCount      *int                             `yaml:"maximum_ruined_count" json:"maximum_ruined_count"`
+	MinimumRuinedPercentage *float64                         `yaml:"minimum_ruined_percentage" json:"minimum_ruined_percentage"`
+	MaximumRuinedPercentage *float64                         `yaml:"maximum_ruined_percentage" json:"maximum_ruined_percentage"`
+	ConstructionHeuristic   *constructionHeuristicConfigFile `yaml:"construction_heuristic" json:"construction_heuristic"`
+}
+
+type pillarSelectorConfigFile struct {
+	SubPillarType        *string `yaml:"sub_pillar_type" json:"sub_pillar_type"`
+	MinimumSubPillarSize *int    `yaml:"minimum_sub_pillar_size" json:"minimum_sub_pillar_size"`
+	MaximumSubPillarSize *int    `yaml:"maximum_sub_pillar_size" json:"maximum_sub_pillar_size"`
+}
+
+type nearbySelectionConfigFile struct {
+	Enabled                             *bool    `yaml:"enabled" json:"enabled"`
+	DistributionType                    *string  `yaml:"distribution_type" json:"distribution_type"`
+	BlockSizeMinimum                    *int     `yaml:"block_size_minimum" json:"block_size_minimum"`
+	BlockSizeMaximum                    *int     `yaml:"block_size_maximum" json:"block_size_maximum"`
+	BlockSizeRatio                      *float64 `yaml:"block_size_ratio" json:"block_size_ratio"`
+	BlockUniformDistributionProbability *float64 `yaml:"block_uniform_distribution_probability" json:"block_uniform_distribution_probability"`
+	LinearSizeMaximum                   *int     `yaml:"linear_size_maximum" json:"linear_size_maximum"`
+	ParabolicSizeMaximum                *int     `yaml:"parabolic_size_maximum" json:"parabolic_size_maximum"`
+	BetaAlpha                           *float64 `yaml:"beta_alpha" json:"beta_alpha"`
+	BetaBeta                            *float64 `yaml:"beta_beta" json:"beta_beta"`
+}
+
+type exhaustiveSearchConfigFile struct {
+	Enabled                *bool                  `yaml:"enabled" json:"enabled"`
+	Type                   *string                `yaml:"type" json:"type"`
+	NodeExplorationType    *string                `yaml:"node_exploration_type" json:"node_exploration_type"`
+	EntitySorterManner     *string                `yaml:"entity_sorter_manner" json:"entity_sorter_manner"`
+	ValueSorterManner      *string                `yaml:"value_sorter_manner" json:"value_sorter_manner"`
+	AssumeScoreOnlyWorsens *bool                  `yaml:"assume_score_only_worsens" json:"assume_score_only_worsens"`
+	Termination            *terminationConfigFile `yaml:"termination" json:"termination"`
+}
+
+type partitionedSearchConfigFile struct {
+	Enabled                 *bool                  `yaml:"enabled" json:"enabled"`
+	RunnablePartThreadLimit *int                   `yaml:"runnable_part_thread_limit" json:"runnable_part_thread_limit"`
+	Termination             *terminationConfigFile `yaml:"termination" json:"termination"`
+}
+
+type tabuSearchConfigFile struct {
+	MinTabuSize        *int      `yaml:"min_tabu_size" json:"min_tabu_size"`
+	MaxTabuSize        *int      `yaml:"max_tabu_size" json:"max_tabu_size"`
+	AspirationCriteria []string  `yaml:"aspiration_criteria" json:"aspiration_criteria"`
+	TimeLimit          *Duration `yaml:"time_limit" json:"time_limit"`
+	MaxFrequency       *int      `yaml:"max_frequency" json:"max_frequency"`
+}
+
+// normalizeEnums 将所有枚举字段转换为大写，配置文件与环境变量中的枚举值不区分大小写
+func (c *SolverConfig) normalizeEnums() {
+	upper(&c.EnvironmentMode)
+	upper(&c.MoveSelector)
+	normalizeTermination(&c.Termination)
+	normalizeConstructionHeuristic(&c.ConstructionHeuristicConfig)
+	upper(&c.LocalSearchConfig.Type)
+	normalizeTermination(&c.LocalSearchConfig.Termination)
+	normalizeConstructionHeuristic(&c.RuinRecreateConfig.ConstructionHeuristicConfig)
+	upper(&c.PillarSelectorConfig.SubPillarType)
+	upper(&c.NearbySelectionConfig.DistributionType)
+	upper(&c.ExhaustiveSearchConfig.Type)
+	upper(&c.ExhaustiveSearchConfig.NodeExplorationType)
+	upper(&c.ExhaustiveSearchConfig.EntitySorterManner)
+	upper(&c.ExhaustiveSearchConfig.ValueSorterManner)
+	normalizeTermination(&c.ExhaustiveSearchConfig.Termination)
+	normalizeTermination(&c.PartitionedSearchConfig.Termination)
+}
+
+func normalizeConstructionHeuristic(ch *ConstructionHeuristicConfig) {
+	upper(&ch.Type)
+	upper(&ch.PickEarlyType)
+	upper(&ch.EntitySorterManner)
+	upper(&ch.ValueSorterManner)
+	normalizeTermination(&ch.Termination)
+}
+
+func normalizeTermination(t *TerminationConfig) {
+	upper(&t.CompositionStyle)
+	for i := range t.Terminations {
+		normalizeTermination(&t.Terminations[i])
+	}
+}
+
+func upper(value *string) {
+	*value = strings.ToUpper(*value)
+}
+
+func (f *solverConfigFile) apply(cfg *SolverConfig, loader *configLoader) error {
+	if f.EnvironmentMode != nil {
+		cfg.EnvironmentMode = *f.EnvironmentMode
+	}
+	if f.TimeLimit != nil {
+		cfg.TimeLimit = toSeconds(time.Duration(*f.TimeLimit))
+	}
+	if f.Parallel != nil {
+		cfg.Parallel = *f.Parallel
+	}
+	if f.ParallelThreadCount != nil {
+		cfg.ParallelThreadCount = *f.ParallelThreadCount
+	}
+	if f.MoveSelector != nil {
+		cfg.MoveSelector = *f.MoveSelector
+	}
+	if f.LocalSearch != nil {
+		cfg.LocalSearch = *f.LocalSearch
+	}
+	if f.NeighborhoodCaching != nil {
+		cfg.NeighborhoodCaching = *f.NeighborhoodCaching
+	}
+	if f.RandomSeed != nil {
+		cfg.RandomSeed = *f.RandomSeed
+	}
+	if f.Termination != nil {
+		// 配置文件中的终止配置整体替换默认终止配置
+		cfg.Termination = TerminationConfig{}
+		if err := f.Termination.apply(&cfg.Termination, loader, "termination"); err != nil {
+			return err
+		}
+	}
+	if f.ConstructionHeuristic != nil {
+		if err := f.ConstructionHeuristic.apply(&cfg.ConstructionHeuristicConfig, loader); err != nil {
+			return err
+		}
+	}
+	if f.LocalSearchConfig != nil {
+		if err := f.LocalSearchConfig.apply(&cfg.LocalSearchConfig, loader); err != nil {
+			return err
+		}
+	}
+	if f.RuinRecreate != nil {
+		if err := f.RuinRecreate.apply(&cfg.RuinRecreateConfig, loader); err != nil {
+			return err
+		}
+	}
+	if f.PillarSelector != nil {
+		f.PillarSelector.apply(&cfg.PillarSelectorConfig)
+	}
+	if f.NearbySelection != nil {
+		// 配置了就近选择且未显式关闭时启用
+		cfg.NearbySelection = f.NearbySelection.Enabled == nil || *f.NearbySelection.Enabled
+		f.NearbySelection.apply(&cfg.NearbySelectionConfig)
+	}
+	if f.ExhaustiveSearch != nil {
+		// 配置了穷举搜索且未显式关闭时启用
+		cfg.ExhaustiveSearch = f.ExhaustiveSearch.Enabled == nil || *f.ExhaustiveSearch.Enabled
+		if err := f.ExhaustiveSearch.apply(&cfg.ExhaustiveSearchConfig, loader); err != nil {
+			return err
+		}
+	}
+	if f.PartitionedSearch != nil {
+		// 配置了分区搜索且未显式关闭时启用
+		cfg.PartitionedSearch = f.PartitionedSearch.Enabled == nil || *f.PartitionedSearch.Enabled
+		if err := f.PartitionedSearch.apply(&cfg.PartitionedSearchConfig, loader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *ruinRecreateConfigFile) apply(cfg *RuinRecreateConfig, loader *configLoader) error {
+	if f.MinimumRuinedCount != nil {
+		cfg.MinimumRuinedCount = *f.MinimumRuinedCount
+	}
+	if f.MaximumRuinedCount != nil {
+		cfg.MaximumRuinedCount = *f.MaximumRuinedCount
+	}
+	if f.MinimumRuinedPercentage != nil {
+		cfg.MinimumRuinedPercentage = *f.MinimumRuinedPercentage
+	}
+	if f.MaximumRuinedPercentage != nil {
+		cfg.MaximumRuinedPercentage = *f.MaximumRuinedPercentage
+	}
+	if f.ConstructionHeuristic != nil {
+		if err := f.ConstructionHeuristic.apply(&cfg.ConstructionHeuristicConfig, loader); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *pillarSelectorConfigFile) apply(cfg *PillarSelectorConfig) {
+	if f.SubPillarType != nil {
+		cfg.SubPillarType = *f.SubPillarType
+	}
+	if f.MinimumSubPillarSize != nil {
+		cfg.MinimumSubPillarSize = *f.MinimumSubPillarSize
+	}
+	if f.MaximumSubPillarSize != nil {
+		cfg.MaximumSubPillarSize = *f.MaximumSubPillarSize
+	}
+}
+
+func (f *nearbySelectionConfigFile) apply(cfg *NearbySelectionConfig) {
+	if f.DistributionType != nil {
+		cfg.DistributionType = *f.DistributionType
+	}
+	if f.BlockSizeMinimum != nil {
+		cfg.BlockSizeMinimum = *f.BlockSizeMinimum
+	}
+	if f.BlockSizeMaximum != nil {
+		cfg.BlockSizeMaximum = *f.BlockSizeMaximum
+	}
+	if f.BlockSizeRatio != nil {
+		cfg.BlockSizeRatio = *f.BlockSizeRatio
+	}
+	if f.BlockUniformDistributionProbability != nil {
+		cfg.BlockUniformDistributionProbability = *f.BlockUniformDistributionProbability
+	}
+	if f.LinearSizeMaximum != nil {
+		cfg.LinearSizeMaximum = *f.LinearSizeMaximum
+	}
+	if f.ParabolicSizeMaximum != nil {
+		cfg.ParabolicSizeMaximum = *f.ParabolicSizeMaximum
+	}
+	if f.BetaAlpha != nil {
+		cfg.BetaAlpha = *f.BetaAlpha
+	}
+	if f.BetaBeta != nil {
+		cfg.BetaBeta = *f.BetaBeta
+	}
+}
+
+func (f *exhaustiveSearchConfigFile) apply(cfg *ExhaustiveSearchConfig, loader *configLoader) error {
+	if f.Type != nil {
+		cfg.Type = *f.Type
+	}
+	if f.NodeExplorationType != nil {
+		cfg.NodeExplorationType = *f.NodeExplorationType
+	}
+	if f.EntitySorterManner != nil {
+		cfg.EntitySorterManner = *f.EntitySorterManner
+	}
+	if f.ValueSorterManner != nil {
+		cfg.ValueSorterManner = *f.ValueSorterManner
+	}
+	if f.AssumeScoreOnlyWorsens != nil {
+		cfg.AssumeScoreOnlyWorsens = *f.AssumeScoreOnlyWorsens
+	}
+	if f.Termination != nil {
+		if err := f.Termination.apply(&cfg.Termination, loader, "exhaustive_search.termination"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *partitionedSearchConfigFile) apply(cfg *PartitionedSearchConfig, loader *configLoader) error {
+	if f.RunnablePartThreadLimit != nil {
+		cfg.RunnablePartThreadLimit = *f.RunnablePartThreadLimit
+	}
+	if f.Termination != nil {
+		if err := f.Termination.apply(&cfg.Termination, loader, "partitioned_search.termination"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *terminationConfigFile) apply(cfg *TerminationConfig, loader *configLoader, path string) error {
+	if f.TimeLimit != nil {
+		cfg.TimeLimit = toSeconds(time.Duration(*f.TimeLimit))
+	}
+	if f.UnimprovedTimeLimit != nil {
+		cfg.UnimprovedTimeLimit = toSeconds(time.Duration(*f.UnimprovedTimeLimit))
+	}
+	if f.UnimprovedStepCountLimit != nil {
+		cfg.UnimprovedStepCountLimit = *f.UnimprovedStepCountLimit
+	}
+	if f.BestScoreLimit != nil {
+		score, err := loader.scoreParser(*f.BestScoreLimit)
+		if err != nil {
+			return fmt.Errorf("%s.best_score_limit: %w", path, err)
+		}
+		cfg.BestScoreLimit = score
+	}
+	if f.BestScoreFeasible != nil {
+		cfg.BestScoreFeasible = *f.BestScoreFeasible
+	}
+	if f.StepCountLimit != nil {
+		cfg.StepCountLimit = *f.StepCountLimit
+	}
+	if f.MoveCountLimit != nil {
+		cfg.MoveCountLimit = *f.MoveCountLimit
+	}
+	if f.CompositionStyle != nil {
+		cfg.CompositionStyle = *f.CompositionStyle
+	}
+	for i := range f.Terminations {
+		child := TerminationConfig{}
+		if err := f.Terminations[i].apply(&child, loader, fmt.Sprintf("%s.terminations[%d]", path, i)); err != nil {
+			return err
+		}
+		cfg.Terminations = append(cfg.Terminations, child)
+	}
+	return nil
+}
+
+func (f *constructionHeuristicConfigFile) apply(cfg *ConstructionHeuristicConfig, loader *configLoader) error {
+	if f.Type != nil {
+		cfg.Type = *f.Type
+	}
+	if f.PickEarlyType != nil {
+		cfg.PickEarlyType = *f.PickEarlyType
+	}
+	if f.EntitySorterManner != nil {
+		cfg.EntitySorterManner = *f.EntitySorterManner
+	}
+	if f.ValueSorterManner != nil {
+		cfg.ValueSorterManner = *f.ValueSorterManner
+	}
+	if f.Termination != nil {
+		return f.Termination.apply(&cfg.Termination, loader, "construction_heuristic.termination")
+	}
+	return nil
+}
+
+func (f *localSearchConfigFile) apply(cfg *LocalSearchConfig, loader *configLoader) error {
+	if f.Type != nil {
+		cfg.Type = *f.Type
+	}
+	if f.TabuSize != nil {
+		cfg.TabuSize = *f.TabuSize
+	}
+	if f.InitialTemperature != nil {
+		cfg.InitialTemperature = *f.InitialTemperature
+	}
+	if f.CoolingRate != nil {
+		cfg.CoolingRate = *f.CoolingRate
+	}
+	if f.TabuSearch != nil {
+		if err := f.TabuSearch.apply(&cfg.TabuSearchConfig); err != nil {
+			return err
+		}
+	}
+	if f.Termination != nil {
+		return f.Termination.apply(&cfg.Termination, loader, "local_search_config.termination")
+	}
+	return nil
+}
+
+func (f *tabuSearchConfigFile) apply(cfg *TabuSearchConfig) error {
+	if f.MinTabuSize != nil {
+		cfg.MinTabuSize = *f.MinTabuSize
+	}
+	if f.MaxTabuSize != nil {
+		cfg.MaxTabuSize = *f.MaxTabuSize
+	}
+	if f.AspirationCriteria != nil {
+		cfg.AspirationCriteria = make([]AspirationCriteria, 0, len(f.AspirationCriteria))
+		for i, name := range f.AspirationCriteria {
+			criteria, err := ParseAspirationCriteria(strings.ToUpper(name))
+			if err != nil {
+				return fmt.Errorf("local_search_config.tabu_search.aspiration_criteria[%d]: %w", i, err)
+			}
+			cfg.AspirationCriteria = append(cfg.AspirationCriteria, criteria)
+		}
+	}
+	if f.TimeLimit != nil {
+		cfg.TimeLimit = time.Duration(*f.TimeLimit)
+	}
+	if f.MaxFrequency != nil {
+		cfg.MaxFrequency = *f.MaxFrequency
+	}
+	return nil
+}
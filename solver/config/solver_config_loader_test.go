@@ -0,0 +1,268 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// noEnv 不使用环境变量覆盖
+var noEnv = WithEnvLookup(nil)
+
+// envOf 从映射中查找环境变量
+func envOf(vars map[string]string) LoaderOption {
+	return WithEnvLookup(func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	})
+}
+
+func TestParseSolverConfigDurations(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"30s", 30},
+		{"1m30s", 90},
+		{"90", 90},
+		{"1500ms", 2},
+		{"0s", 0},
+	}
+	for _, tt := range tests {
+		cfg, err := ParseSolverConfig([]byte("time_limit: "+tt.value+"\ntermination:\n  unimproved_time_limit: "+tt.value), ConfigFormatYAML, noEnv)
+		if err != nil {
+			t.Errorf("%s: %v", tt.value, err)
+			continue
+		}
+		if cfg.TimeLimit != tt.want || cfg.Termination.UnimprovedTimeLimit != tt.want {
+			t.Errorf("%s: got %d and %d seconds, want %d", tt.value, cfg.TimeLimit, cfg.Termination.UnimprovedTimeLimit, tt.want)
+		}
+	}
+
+	cfg, err := ParseSolverConfig([]byte(`{"time_limit": 45, "termination": {"time_limit": "2m"}}`), ConfigFormatJSON, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TimeLimit != 45 || cfg.Termination.TimeLimit != 120 {
+		t.Errorf("JSON durations: got %d and %d seconds, want 45 and 120", cfg.TimeLimit, cfg.Termination.TimeLimit)
+	}
+}
+
+func TestParseSolverConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		want   []string
+	}{
+		{"invalid duration", "time_limit: soon", ConfigFormatYAML, []string{`invalid duration "soon"`, `"30s"`}},
+		{"negative duration", "time_limit: -5s", ConfigFormatYAML, []string{`invalid duration "-5s", must not be negative`}},
+		{"unknown field", "time_limt: 30s", ConfigFormatYAML, []string{"failed to parse YAML", "time_limt"}},
+		{"unknown JSON field", `{"move_selecter": "RANDOM"}`, ConfigFormatJSON, []string{"failed to parse JSON", "unknown field"}},
+		{"invalid score", "termination:\n  best_score_limit: 0hard", ConfigFormatYAML, []string{"termination.best_score_limit", `invalid score "0hard"`}},
+		{"invalid nested score", "local_search_config:\n  termination:\n    terminations:\n      - best_score_limit: abc", ConfigFormatYAML,
+			[]string{"local_search_config.termination.terminations[0].best_score_limit"}},
+		{"invalid enum", "move_selector: sideways", ConfigFormatYAML, []string{"MoveSelector", "SIDEWAYS"}},
+		{"unsupported format", "", "TOML", []string{`unsupported config format "TOML"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSolverConfig([]byte(tt.data), tt.format, noEnv)
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSolverConfigScores(t *testing.T) {
+	data := `
+termination:
+  best_score_limit: 0hard/-10soft
+construction_heuristic:
+  termination:
+    best_score_limit: -1init/0hard/0soft
+`
+	cfg, err := ParseSolverConfig([]byte(data), ConfigFormatYAML, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Termination.BestScoreLimit.ToShortString(); got != "HardSoftScore[initScore=0, hardScore=0, softScore=-10]" {
+		t.Errorf("termination best score limit: got %s", got)
+	}
+	if got := cfg.ConstructionHeuristicConfig.Termination.BestScoreLimit.ToShortString(); got != "HardSoftScore[initScore=-1, hardScore=0, softScore=0]" {
+		t.Errorf("construction heuristic best score limit: got %s", got)
+	}
+}
+
+func TestParseSolverConfigNestedTerminations(t *testing.T) {
+	data := `
+termination:
+  composition_style: and
+  move_count_limit: 1000
+  terminations:
+    - step_count_limit: 50
+      best_score_feasible: true
+    - composition_style: or
+      terminations:
+        - unimproved_step_count_limit: 20
+`
+	cfg, err := ParseSolverConfig([]byte(data), ConfigFormatYAML, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	termination := cfg.Termination
+	if termination.CompositionStyle != TerminationCompositionStyleAnd || termination.MoveCountLimit != 1000 {
+		t.Errorf("top-level termination: got %+v", termination)
+	}
+	if len(termination.Terminations) != 2 {
+		t.Fatalf("got %d nested terminations, want 2", len(termination.Terminations))
+	}
+	if first := termination.Terminations[0]; first.StepCountLimit != 50 || !first.BestScoreFeasible {
+		t.Errorf("first nested termination: got %+v", first)
+	}
+	second := termination.Terminations[1]
+	if second.CompositionStyle != TerminationCompositionStyleOr || len(second.Terminations) != 1 ||
+		second.Terminations[0].UnimprovedStepCountLimit != 20 {
+		t.Errorf("second nested termination: got %+v", second)
+	}
+}
+
+func TestParseSolverConfigNormalizesEnums(t *testing.T) {
+	data := `
+environment_mode: reproducible
+move_selector: random_change
+construction_heuristic:
+  type: first_fit
+  pick_early_type: first_non_deteriorating_score
+local_search_config:
+  type: tabu_search
+  tabu_search:
+    min_tabu_size: 5
+    max_tabu_size: 10
+pillar_selector:
+  sub_pillar_type: none
+exhaustive_search:
+  type: branch_and_bound
+`
+	cfg, err := ParseSolverConfig([]byte(data), ConfigFormatYAML, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{
+		cfg.EnvironmentMode,
+		cfg.MoveSelector,
+		cfg.ConstructionHeuristicConfig.Type,
+		cfg.ConstructionHeuristicConfig.PickEarlyType,
+		cfg.LocalSearchConfig.Type,
+		cfg.PillarSelectorConfig.SubPillarType,
+		cfg.ExhaustiveSearchConfig.Type,
+	}
+	for _, value := range got {
+		if value != strings.ToUpper(value) {
+			t.Errorf("enum %q was not upper-cased", value)
+		}
+	}
+	if cfg.LocalSearchConfig.Type != LocalSearchTypeTabuSearch {
+		t.Errorf("local search type: got %q, want %q", cfg.LocalSearchConfig.Type, LocalSearchTypeTabuSearch)
+	}
+}
+
+func TestParseSolverConfigEnvOverrides(t *testing.T) {
+	data := "time_limit: 30s\nmove_selector: BEST_FIT"
+	cfg, err := ParseSolverConfig([]byte(data), ConfigFormatYAML, envOf(map[string]string{
+		"TIMEFOLD_TIME_LIMIT":                   "2m",
+		"TIMEFOLD_MOVE_SELECTOR":                "random",
+		"TIMEFOLD_PARALLEL":                     "false",
+		"TIMEFOLD_RANDOM_SEED":                  "42",
+		"TIMEFOLD_LOCAL_SEARCH_TYPE":            "simulated_annealing",
+		"TIMEFOLD_TERMINATION_BEST_SCORE_LIMIT": "0hard/0soft",
+		"TIMEFOLD_TERMINATION_MOVE_COUNT_LIMIT": " 500 ",
+		// 没有前缀的变量被忽略
+		"STEP_COUNT_LIMIT": "7",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TimeLimit != 120 {
+		t.Errorf("time limit: got %d, want 120 from the environment", cfg.TimeLimit)
+	}
+	if cfg.MoveSelector != MOVE_SELECTOR_RANDOM {
+		t.Errorf("move selector: got %q, want %q", cfg.MoveSelector, MOVE_SELECTOR_RANDOM)
+	}
+	if cfg.Parallel || cfg.RandomSeed != 42 {
+		t.Errorf("got parallel %v and random seed %d, want false and 42", cfg.Parallel, cfg.RandomSeed)
+	}
+	if cfg.LocalSearchConfig.Type != LocalSearchTypeSimulatedAnnealing {
+		t.Errorf("local search type: got %q", cfg.LocalSearchConfig.Type)
+	}
+	if cfg.Termination.BestScoreLimit == nil || cfg.Termination.MoveCountLimit != 500 {
+		t.Errorf("termination: got %+v", cfg.Termination)
+	}
+
+	cfg, err = ParseSolverConfig([]byte(data), ConfigFormatYAML, WithEnvPrefix("APP_"), envOf(map[string]string{
+		"TIMEFOLD_TIME_LIMIT": "2m",
+		"APP_TIME_LIMIT":      "10",
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.TimeLimit != 10 {
+		t.Errorf("custom prefix: got time limit %d, want 10", cfg.TimeLimit)
+	}
+}
+
+func TestParseSolverConfigEnvErrors(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       string
+	}{
+		{"TIMEFOLD_PARALLEL", "maybe", `environment variable TIMEFOLD_PARALLEL: invalid boolean "maybe"`},
+		{"TIMEFOLD_PARALLEL_THREAD_COUNT", "four", `environment variable TIMEFOLD_PARALLEL_THREAD_COUNT: invalid integer "four"`},
+		{"TIMEFOLD_RANDOM_SEED", "x", `environment variable TIMEFOLD_RANDOM_SEED: invalid integer "x"`},
+		{"TIMEFOLD_TIME_LIMIT", "later", `environment variable TIMEFOLD_TIME_LIMIT: invalid duration "later"`},
+		{"TIMEFOLD_TERMINATION_BEST_SCORE_LIMIT", "best", `environment variable TIMEFOLD_TERMINATION_BEST_SCORE_LIMIT: invalid score "best"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseSolverConfig(nil, ConfigFormatYAML, envOf(map[string]string{tt.key: tt.value}))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s=%s: got error %v, want %q", tt.key, tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestLoadSolverConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solver.yml")
+	if err := os.WriteFile(path, []byte("move_selector: change\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadSolverConfig(path, noEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MoveSelector != MOVE_SELECTOR_CHANGE {
+		t.Errorf("move selector: got %q, want %q", cfg.MoveSelector, MOVE_SELECTOR_CHANGE)
+	}
+	if defaults := NewDefalutSolverConfig(); cfg.TimeLimit != defaults.TimeLimit {
+		t.Errorf("unset time limit: got %d, want the default %d", cfg.TimeLimit, defaults.TimeLimit)
+	}
+
+	bad := filepath.Join(dir, "solver.yml")
+	if err := os.WriteFile(bad, []byte("parallel: sometimes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSolverConfig(bad, noEnv); err == nil || !strings.Contains(err.Error(), "invalid solver config "+bad) {
+		t.Errorf("got error %v, want it to name the file", err)
+	}
+	if _, err := LoadSolverConfig(filepath.Join(dir, "solver.toml"), noEnv); err == nil ||
+		!strings.Contains(err.Error(), "expected .yaml, .yml or .json") {
+		t.Errorf("got error %v for an unsupported extension", err)
+	}
+}
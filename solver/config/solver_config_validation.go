@@ -0,0 +1,337 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	environmentModes = []string{
+		EnvironmentModeReproducible,
+		EnvironmentModeNonReproducible,
+		EnvironmentModeFastAssert,
+		EnvironmentModeStepAssert,
+		EnvironmentModeFullAssert,
+	}
+	moveSelectors = []string{
+		MOVE_SELECTOR_FIRST_FIT,
+		MOVE_SELECTOR_BEST_FIT,
+		MOVE_SELECTOR_CHANGE,
+		MOVE_SELECTOR_CHAINED,
+		MOVE_SELECTOR_RANDOM,
+		MOVE_SELECTOR_RUIN_RECREATE,
+		MOVE_SELECTOR_PILLAR_CHANGE,
+		MOVE_SELECTOR_PILLAR_SWAP,
+		MOVE_SELECTOR_RANDOM_CHANGE,
+	}
+	nearbyDistributions = []string{
+		NearbyDistributionBlock,
+		NearbyDistributionLinear,
+		NearbyDistributionParabolic,
+		NearbyDistributionBeta,
+	}
+	subPillarTypes = []string{
+		SubPillarTypeNone,
+		SubPillarTypeAll,
+		SubPillarTypeSequence,
+	}
+	localSearchTypes = []string{
+		LocalSearchTypeSimulatedAnnealing,
+		LocalSearchTypeTabuSearch,
+	}
+	constructionHeuristicTypes = []string{
+		ConstructionHeuristicTypeFirstFit,
+		ConstructionHeuristicTypeFirstFitDecreasing,
+		ConstructionHeuristicTypeWeakestFit,
+		ConstructionHeuristicTypeWeakestFitDecreasing,
+		ConstructionHeuristicTypeStrongestFit,
+		ConstructionHeuristicTypeStrongestFitDecreasing,
+		ConstructionHeuristicTypeAllocateEntityFromQueue,
+		ConstructionHeuristicTypeAllocateToValueFromQueue,
+		ConstructionHeuristicTypeCheapestInsertion,
+	}
+	// 支持自定义排序方式的构造启发式类型
+	sortableConstructionHeuristicTypes = []string{
+		ConstructionHeuristicTypeAllocateEntityFromQueue,
+		ConstructionHeuristicTypeAllocateToValueFromQueue,
+		ConstructionHeuristicTypeCheapestInsertion,
+	}
+	pickEarlyTypes = []string{
+		PickEarlyTypeNever,
+		PickEarlyTypeFirstNonDeterioratingScore,
+		PickEarlyTypeFirstFeasibleScore,
+	}
+	entitySorterManners = []string{
+		EntitySorterMannerNone,
+		EntitySorterMannerDecreasingDifficulty,
+	}
+	valueSorterManners = []string{
+		ValueSorterMannerNone,
+		ValueSorterMannerIncreasingStrength,
+		ValueSorterMannerDecreasingStrength,
+	}
+	exhaustiveSearchTypes = []string{
+		ExhaustiveSearchTypeBruteForce,
+		ExhaustiveSearchTypeBranchAndBound,
+	}
+	nodeExplorationTypes = []string{
+		NodeExplorationTypeDepthFirst,
+		NodeExplorationTypeBreadthFirst,
+		NodeExplorationTypeScoreFirst,
+		NodeExplorationTypeOptimisticBoundFirst,
+	}
+	compositionStyles = []string{
+		TerminationCompositionStyleOr,
+		TerminationCompositionStyleAnd,
+	}
+)
+
+// Validate 校验配置中的枚举值、取值范围与字段间的依赖关系
+// 返回的错误包含所有问题，可通过 errors.Join 的 Unwrap() []error 逐条获取
+func (c *SolverConfig) Validate() error {
+	v := &validator{}
+	v.checkEnum("EnvironmentMode", c.EnvironmentMode, environmentModes, true)
+	if c.TimeLimit < 0 {
+		v.addf("TimeLimit must not be negative, got %d", c.TimeLimit)
+	}
+	if c.Parallel && c.ParallelThreadCount < 1 {
+		v.addf("ParallelThreadCount must be at least 1 when Parallel is enabled, got %d", c.ParallelThreadCount)
+	}
+	v.checkEnum("MoveSelector", c.MoveSelector, moveSelectors, false)
+	switch c.MoveSelector {
+	case MOVE_SELECTOR_RUIN_RECREATE:
+		v.checkRuinRecreate("RuinRecreateConfig", &c.RuinRecreateConfig)
+	case MOVE_SELECTOR_PILLAR_CHANGE, MOVE_SELECTOR_PILLAR_SWAP:
+		v.checkPillarSelector("PillarSelectorConfig", &c.PillarSelectorConfig)
+	}
+	v.checkTermination("Termination", &c.Termination)
+	v.checkConstructionHeuristic("ConstructionHeuristicConfig", &c.ConstructionHeuristicConfig)
+	if c.LocalSearch {
+		v.checkLocalSearch("LocalSearchConfig", &c.LocalSearchConfig)
+	}
+	if c.NearbySelection {
+		v.checkNearbySelection("NearbySelectionConfig", &c.NearbySelectionConfig)
+	}
+	if c.ExhaustiveSearch {
+		v.checkExhaustiveSearch("ExhaustiveSearchConfig", &c.ExhaustiveSearchConfig)
+		if c.PartitionedSearch {
+			v.addf("ExhaustiveSearch and PartitionedSearch must not be enabled together")
+		}
+	}
+	if c.PartitionedSearch {
+		v.checkPartitionedSearch("PartitionedSearchConfig", &c.PartitionedSearchConfig)
+	}
+	return v.err()
+}
+
+// 配置校验器，收集所有问题
+type validator struct {
+	problems []error
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Errorf(format, args...))
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return errors.Join(v.problems...)
+}
+
+// checkEnum 校验枚举值，allowEmpty 为 true 时允许未设置
+func (v *validator) checkEnum(field, value string, allowed []string, allowEmpty bool) {
+	if value == "" && allowEmpty {
+		return
+	}
+	if !contains(allowed, value) {
+		v.addf("%s has unknown value %q, expected one of %s", field, value, strings.Join(allowed, ", "))
+	}
+}
+
+func (v *validator) checkTermination(field string, t *TerminationConfig) {
+	if t.TimeLimit < 0 {
+		v.addf("%s.TimeLimit must not be negative, got %d", field, t.TimeLimit)
+	}
+	if t.UnimprovedTimeLimit < 0 {
+		v.addf("%s.UnimprovedTimeLimit must not be negative, got %d", field, t.UnimprovedTimeLimit)
+	}
+	if t.UnimprovedStepCountLimit < 0 {
+		v.addf("%s.UnimprovedStepCountLimit must not be negative, got %d", field, t.UnimprovedStepCountLimit)
+	}
+	if t.StepCountLimit < 0 {
+		v.addf("%s.StepCountLimit must not be negative, got %d", field, t.StepCountLimit)
+	}
+	if t.MoveCountLimit < 0 {
+		v.addf("%s.MoveCountLimit must not be negative, got %d", field, t.MoveCountLimit)
+	}
+	v.checkEnum(field+".CompositionStyle", t.CompositionStyle, compositionStyles, true)
+	for i := range t.Terminations {
+		v.checkTermination(fmt.Sprintf("%s.Terminations[%d]", field, i), &t.Terminations[i])
+	}
+}
+
+func (v *validator) checkConstructionHeuristic(field string, ch *ConstructionHeuristicConfig) {
+	v.checkEnum(field+".Type", ch.Type, constructionHeuristicTypes, true)
+	v.checkEnum(field+".PickEarlyType", ch.PickEarlyType, pickEarlyTypes, true)
+	v.checkEnum(field+".EntitySorterManner", ch.EntitySorterManner, entitySorterManners, true)
+	v.checkEnum(field+".ValueSorterManner", ch.ValueSorterManner, valueSorterManners, true)
+	if !contains(sortableConstructionHeuristicTypes, ch.Type) {
+		if ch.EntitySorterManner != "" {
+			v.addf("%s.EntitySorterManner is only supported with Type %s, got Type %q",
+				field, strings.Join(sortableConstructionHeuristicTypes, ", "), ch.Type)
+		}
+		if ch.ValueSorterManner != "" {
+			v.addf("%s.ValueSorterManner is only supported with Type %s, got Type %q",
+				field, strings.Join(sortableConstructionHeuristicTypes, ", "), ch.Type)
+		}
+	}
+	v.checkTermination(field+".Termination", &ch.Termination)
+}
+
+func (v *validator) checkLocalSearch(field string, ls *LocalSearchConfig) {
+	v.checkEnum(field+".Type", ls.Type, localSearchTypes, false)
+	if ls.TabuSize < 0 {
+		v.addf("%s.TabuSize must not be negative, got %d", field, ls.TabuSize)
+	}
+	switch ls.Type {
+	case LocalSearchTypeSimulatedAnnealing:
+		if ls.InitialTemperature <= 0 {
+			v.addf("%s.InitialTemperature must be positive for %s, got %g", field, ls.Type, ls.InitialTemperature)
+		}
+		if ls.CoolingRate <= 0 || ls.CoolingRate > 1 {
+			v.addf("%s.CoolingRate must be in (0, 1] for %s, got %g", field, ls.Type, ls.CoolingRate)
+		}
+	case LocalSearchTypeTabuSearch:
+		v.checkTabuSearch(field+".TabuSearchConfig", &ls.TabuSearchConfig)
+	}
+	v.checkTermination(field+".Termination", &ls.Termination)
+}
+
+func (v *validator) checkRuinRecreate(field string, rr *RuinRecreateConfig) {
+	if rr.IsPercentageBased() {
+		if rr.MinimumRuinedPercentage < 0 || rr.MinimumRuinedPercentage > 1 {
+			v.addf("%s.MinimumRuinedPercentage must be in [0, 1], got %g", field, rr.MinimumRuinedPercentage)
+		}
+		if rr.MaximumRuinedPercentage <= 0 || rr.MaximumRuinedPercentage > 1 {
+			v.addf("%s.MaximumRuinedPercentage must be in (0, 1], got %g", field, rr.MaximumRuinedPercentage)
+		}
+		if rr.MaximumRuinedPercentage < rr.MinimumRuinedPercentage {
+			v.addf("%s.MaximumRuinedPercentage (%g) must not be less than MinimumRuinedPercentage (%g)",
+				field, rr.MaximumRuinedPercentage, rr.MinimumRuinedPercentage)
+		}
+	} else {
+		if rr.MinimumRuinedCount < 1 {
+			v.addf("%s.MinimumRuinedCount must be at least 1, got %d", field, rr.MinimumRuinedCount)
+		}
+		if rr.MaximumRuinedCount < rr.MinimumRuinedCount {
+			v.addf("%s.MaximumRuinedCount (%d) must not be less than MinimumRuinedCount (%d)",
+				field, rr.MaximumRuinedCount, rr.MinimumRuinedCount)
+		}
+	}
+	v.checkConstructionHeuristic(field+".ConstructionHeuristicConfig", &rr.ConstructionHeuristicConfig)
+}
+
+func (v *validator) checkPillarSelector(field string, ps *PillarSelectorConfig) {
+	v.checkEnum(field+".SubPillarType", ps.SubPillarType, subPillarTypes, true)
+	if ps.MinimumSubPillarSize < 1 {
+		v.addf("%s.MinimumSubPillarSize must be at least 1, got %d", field, ps.MinimumSubPillarSize)
+	}
+	if ps.MaximumSubPillarSize < 0 {
+		v.addf("%s.MaximumSubPillarSize must not be negative, got %d", field, ps.MaximumSubPillarSize)
+	}
+	if ps.MaximumSubPillarSize > 0 && ps.MaximumSubPillarSize < ps.MinimumSubPillarSize {
+		v.addf("%s.MaximumSubPillarSize (%d) must not be less than MinimumSubPillarSize (%d)",
+			field, ps.MaximumSubPillarSize, ps.MinimumSubPillarSize)
+	}
+	if (ps.SubPillarType == "" || ps.SubPillarType == SubPillarTypeNone) && (ps.MinimumSubPillarSize > 1 || ps.MaximumSubPillarSize > 0) {
+		v.addf("%s sub pillar sizes are only supported with SubPillarType %s or %s", field, SubPillarTypeAll, SubPillarTypeSequence)
+	}
+}
+
+func (v *validator) checkNearbySelection(field string, ns *NearbySelectionConfig) {
+	v.checkEnum(field+".DistributionType", ns.DistributionType, nearbyDistributions, true)
+	switch ns.DistributionType {
+	case NearbyDistributionBlock:
+		if ns.BlockSizeMinimum < 0 {
+			v.addf("%s.BlockSizeMinimum must not be negative, got %d", field, ns.BlockSizeMinimum)
+		}
+		if ns.BlockSizeMaximum > 0 && ns.BlockSizeMaximum < ns.BlockSizeMinimum {
+			v.addf("%s.BlockSizeMaximum (%d) must not be less than BlockSizeMinimum (%d)", field, ns.BlockSizeMaximum, ns.BlockSizeMinimum)
+		}
+		if ns.BlockSizeRatio < 0 || ns.BlockSizeRatio > 1 {
+			v.addf("%s.BlockSizeRatio must be in [0, 1], got %g", field, ns.BlockSizeRatio)
+		}
+		if ns.BlockUniformDistributionProbability < 0 || ns.BlockUniformDistributionProbability > 1 {
+			v.addf("%s.BlockUniformDistributionProbability must be in [0, 1], got %g", field, ns.BlockUniformDistributionProbability)
+		}
+	case NearbyDistributionBeta:
+		if ns.BetaAlpha <= 0 {
+			v.addf("%s.BetaAlpha must be positive, got %g", field, ns.BetaAlpha)
+		}
+		if ns.BetaBeta <= 0 {
+			v.addf("%s.BetaBeta must be positive, got %g", field, ns.BetaBeta)
+		}
+	}
+	if ns.LinearSizeMaximum < 0 {
+		v.addf("%s.LinearSizeMaximum must not be negative, got %d", field, ns.LinearSizeMaximum)
+	}
+	if ns.ParabolicSizeMaximum < 0 {
+		v.addf("%s.ParabolicSizeMaximum must not be negative, got %d", field, ns.ParabolicSizeMaximum)
+	}
+}
+
+func (v *validator) checkExhaustiveSearch(field string, es *ExhaustiveSearchConfig) {
+	v.checkEnum(field+".Type", es.Type, exhaustiveSearchTypes, true)
+	v.checkEnum(field+".NodeExplorationType", es.NodeExplorationType, nodeExplorationTypes, true)
+	v.checkEnum(field+".EntitySorterManner", es.EntitySorterManner, entitySorterManners, true)
+	v.checkEnum(field+".ValueSorterManner", es.ValueSorterManner, valueSorterManners, true)
+	if es.Type == ExhaustiveSearchTypeBruteForce && es.NodeExplorationType != "" && es.NodeExplorationType != NodeExplorationTypeDepthFirst {
+		v.addf("%s.NodeExplorationType %s is only supported with Type %s", field, es.NodeExplorationType, ExhaustiveSearchTypeBranchAndBound)
+	}
+	v.checkTermination(field+".Termination", &es.Termination)
+}
+
+func (v *validator) checkPartitionedSearch(field string, ps *PartitionedSearchConfig) {
+	if ps.RunnablePartThreadLimit < 0 {
+		v.addf("%s.RunnablePartThreadLimit must not be negative, got %d", field, ps.RunnablePartThreadLimit)
+	}
+	v.checkTermination(field+".Termination", &ps.Termination)
+}
+
+func (v *validator) checkTabuSearch(field string, ts *TabuSearchConfig) {
+	if ts.MinTabuSize < 1 {
+		v.addf("%s.MinTabuSize must be at least 1, got %d", field, ts.MinTabuSize)
+	}
+	if ts.MaxTabuSize < ts.MinTabuSize {
+		v.addf("%s.MaxTabuSize (%d) must not be less than MinTabuSize (%d)", field, ts.MaxTabuSize, ts.MinTabuSize)
+	}
+	if ts.TimeLimit < 0 {
+		v.addf("%s.TimeLimit must not be negative, got %s", field, ts.TimeLimit)
+	}
+	if ts.MaxFrequency < 0 {
+		v.addf("%s.MaxFrequency must not be negative, got %d", field, ts.MaxFrequency)
+	}
+	for i, criteria := range ts.AspirationCriteria {
+		if _, ok := aspirationCriteriaNames[criteria]; !ok {
+			v.addf("%s.AspirationCriteria[%d] has unknown value %d", field, i, int(criteria))
+		}
+		if criteria == TIME_BASED && ts.TimeLimit <= 0 {
+			v.addf("%s.TimeLimit must be positive when AspirationCriteria contains %s", field, TIME_BASED)
+		}
+		if criteria == FREQUENCY_BASED && ts.MaxFrequency <= 0 {
+			v.addf("%s.MaxFrequency must be positive when AspirationCriteria contains %s", field, FREQUENCY_BASED)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateDefaultConfig(t *testing.T) {
+	if err := NewDefalutSolverConfig().Validate(); err != nil {
+		t.Errorf("default config is invalid: %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := NewDefalutSolverConfig()
+	cfg.EnvironmentMode = "CAREFUL"
+	cfg.TimeLimit = -1
+	cfg.ParallelThreadCount = 0
+	cfg.Termination.Terminations = []TerminationConfig{{CompositionStyle: "XOR"}}
+	cfg.ConstructionHeuristicConfig.Type = ConstructionHeuristicTypeFirstFit
+	cfg.ConstructionHeuristicConfig.ValueSorterManner = ValueSorterMannerIncreasingStrength
+	cfg.LocalSearchConfig.Type = "LATE_ACCEPTANCE"
+	cfg.ExhaustiveSearch = true
+	cfg.ExhaustiveSearchConfig.Type = ExhaustiveSearchTypeBruteForce
+	cfg.PartitionedSearch = true
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	want := []string{
+		`EnvironmentMode has unknown value "CAREFUL"`,
+		"TimeLimit must not be negative, got -1",
+		"ParallelThreadCount must be at least 1 when Parallel is enabled, got 0",
+		`Termination.Terminations[0].CompositionStyle has unknown value "XOR"`,
+		"ConstructionHeuristicConfig.ValueSorterManner is only supported with Type",
+		`LocalSearchConfig.Type has unknown value "LATE_ACCEPTANCE", expected one of SIMULATED_ANNEALING, TABU_SEARCH`,
+		"ExhaustiveSearch and PartitionedSearch must not be enabled together",
+	}
+	for _, problem := range want {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error does not report %q:\n%v", problem, err)
+		}
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("got %T, want an error joining every problem", err)
+	}
+	if got := len(joined.Unwrap()); got < len(want) {
+		t.Errorf("got %d problems, want at least %d", got, len(want))
+	}
+}
@@ -1,6 +1,9 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // AspirationCriteria 特赦准则类型
 type AspirationCriteria int
@@ -13,6 +16,31 @@ const (
 	FREQUENCY_BASED                    // 基于频率的特赦
 )
 
+var aspirationCriteriaNames = map[AspirationCriteria]string{
+	NONE:            "NONE",
+	BEST_SCORE:      "BEST_SCORE",
+	IMPROVING:       "IMPROVING",
+	TIME_BASED:      "TIME_BASED",
+	FREQUENCY_BASED: "FREQUENCY_BASED",
+}
+
+func (c AspirationCriteria) String() string {
+	if name, ok := aspirationCriteriaNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("AspirationCriteria(%d)", int(c))
+}
+
+// ParseAspirationCriteria 根据名称解析特赦准则
+func ParseAspirationCriteria(name string) (AspirationCriteria, error) {
+	for criteria, criteriaName := range aspirationCriteriaNames {
+		if criteriaName == name {
+			return criteria, nil
+		}
+	}
+	return NONE, fmt.Errorf("unknown aspiration criteria %q", name)
+}
+
 // 禁忌搜索配置
 type TabuSearchConfig struct {
 	// 最小禁忌步长
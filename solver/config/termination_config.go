@@ -2,13 +2,29 @@ package config
 
 import "github.com/kruily/go-timefold-solver/solver/api"
 
+const (
+	// 终止条件组合方式
+	TerminationCompositionStyleOr  = "OR"
+	TerminationCompositionStyleAnd = "AND"
+)
+
 type TerminationConfig struct {
 	// 时间限制（秒）
 	TimeLimit int
+	// 未改进时间限制（秒）
+	UnimprovedTimeLimit int
 	// 未改进步数限制
 	UnimprovedStepCountLimit int
 	// 最佳分数限制
 	BestScoreLimit api.IScore
+	// 最佳分数可行时终止
+	BestScoreFeasible bool
 	// 步数限制
 	StepCountLimit int
+	// 移动评估次数限制
+	MoveCountLimit int64
+	// 组合方式，默认任一条件满足即终止
+	CompositionStyle string // "OR", "AND"
+	// 嵌套的终止配置，与本配置中的条件按组合方式组合
+	Terminations []TerminationConfig
 }
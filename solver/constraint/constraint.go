@@ -2,7 +2,7 @@ package constraint
 
 import (
 	"github.com/kruily/go-timefold-solver/solver/api"
-	"github.com/kruily/go-timefold-solver/solver/score"
+	score "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
 )
 
 type ConstraintType int
@@ -19,8 +19,17 @@ type Constraint struct {
 	Weight int
 	// 约束类型
 	Type ConstraintType
-	// 约束匹配函数
+	// 单位分数，设置后代替 Type，用于 HardSoftScore 以外的分数类型，所有约束的分数类型必须一致
+	Score api.IScore
+	// 约束匹配函数，匹配时匹配权重为 1
 	MatchFunc func(solution api.ISolution) bool
+	// 匹配权重函数，如 "每加班一分钟惩罚 1" 返回加班的分钟数，优先于 MatchFunc
+	MatchWeightFunc func(solution api.ISolution) int
+	// 匹配列表函数，每次匹配带有权重与说明对象，用于分数明细
+	// 未设置 MatchWeightFunc 时计算分数也对匹配权重求和，同时设置 MatchWeightFunc 可以避免求解时生成说明对象
+	MatchesFunc func(solution api.ISolution) []api.ConstraintMatch
+	// 约束所属的包（分组），如 "shift" 或 "employee"
+	Package string
 }
 
 func NewConstraint(options ...func(*Constraint)) *Constraint {
@@ -49,27 +58,104 @@ func WithType(constraintType ConstraintType) func(*Constraint) {
 	}
 }
 
+func WithScore(unitScore api.IScore) func(*Constraint) {
+	return func(constraint *Constraint) {
+		constraint.Score = unitScore
+	}
+}
+
 func WithMatchFunc(matchFunc func(solution api.ISolution) bool) func(*Constraint) {
 	return func(constraint *Constraint) {
 		constraint.MatchFunc = matchFunc
 	}
 }
 
+func WithMatchWeightFunc(matchWeightFunc func(solution api.ISolution) int) func(*Constraint) {
+	return func(constraint *Constraint) {
+		constraint.MatchWeightFunc = matchWeightFunc
+	}
+}
+
+func WithMatchesFunc(matchesFunc func(solution api.ISolution) []api.ConstraintMatch) func(*Constraint) {
+	return func(constraint *Constraint) {
+		constraint.MatchesFunc = matchesFunc
+	}
+}
+
+// BoolMatchWeight 将布尔匹配函数适配为匹配权重函数，匹配时权重为 1
+func BoolMatchWeight(matchFunc func(solution api.ISolution) bool) func(solution api.ISolution) int {
+	return func(solution api.ISolution) int {
+		if matchFunc(solution) {
+			return 1
+		}
+		return 0
+	}
+}
+
+func WithPackage(constraintPackage string) func(*Constraint) {
+	return func(constraint *Constraint) {
+		constraint.Package = constraintPackage
+	}
+}
+
+// GetScore 获取约束单次匹配的单位分数，由分数计算器乘以权重
 func (c *Constraint) GetScore() api.IScore {
+	if c.Score != nil {
+		return c.Score
+	}
 	switch c.Type {
 	case HARD:
-		return score.NewHardSoftScore(c.Weight, 0, 0)
+		return score.ONE_HARD
 	case SOFT:
-		return score.NewHardSoftScore(0, c.Weight, 0)
+		return score.ONE_SOFT
 	default:
 		return score.NewHardSoftScore(0, 0, 0)
 	}
 }
 
 func (c *Constraint) Match(solution api.ISolution) bool {
-	return c.MatchFunc(solution)
+	return c.MatchWeight(solution) != 0
+}
+
+// MatchWeight 获取匹配权重之和，优先使用 MatchWeightFunc，只设置了 MatchesFunc 时对匹配权重求和
+func (c *Constraint) MatchWeight(solution api.ISolution) int {
+	if c.MatchWeightFunc == nil && c.MatchesFunc != nil {
+		total := 0
+		for _, match := range c.MatchesFunc(solution) {
+			total += match.Weight
+		}
+		return total
+	}
+	return c.matchWeightFunc()(solution)
+}
+
+// Matches 获取每次匹配的权重与说明对象，未设置 MatchesFunc 时整个约束作为一次没有说明对象的匹配
+func (c *Constraint) Matches(solution api.ISolution) []api.ConstraintMatch {
+	if c.MatchesFunc != nil {
+		return c.MatchesFunc(solution)
+	}
+	weight := c.matchWeightFunc()(solution)
+	if weight == 0 {
+		return nil
+	}
+	return []api.ConstraintMatch{{Weight: weight}}
+}
+
+func (c *Constraint) matchWeightFunc() func(solution api.ISolution) int {
+	if c.MatchWeightFunc != nil {
+		return c.MatchWeightFunc
+	}
+	return BoolMatchWeight(c.MatchFunc)
+}
+
+func (c *Constraint) GetName() string {
+	return c.Name
 }
 
 func (c *Constraint) GetWeight() int {
 	return c.Weight
 }
+
+func (c *Constraint) GetPackage() string {
+	return c.Package
+}
@@ -15,7 +15,7 @@ func (c *ConstraintManager) AddConstraint(constraint *Constraint) {
 }
 
 func (c *ConstraintManager) GetConstraints() []api.IConstraint {
-	result := make([]api.IConstraint, 0)
+	result := make([]api.IConstraint, len(c.constraints))
 	for i, c := range c.constraints {
 		result[i] = c
 	}
@@ -0,0 +1,34 @@
+package constraint
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// MatchWeightOf 获取约束的匹配权重，约束未实现 api.IWeightedConstraint 时满足为 1，不满足为 0
+func MatchWeightOf(c api.IConstraint, solution api.ISolution) int {
+	if weighted, ok := c.(api.IWeightedConstraint); ok {
+		return weighted.MatchWeight(solution)
+	}
+	if c.Match(solution) {
+		return 1
+	}
+	return 0
+}
+
+// MatchesOf 获取约束的每次匹配，约束未实现 api.IJustifiedConstraint 时匹配权重不为 0 则作为一次没有说明对象的匹配
+func MatchesOf(c api.IConstraint, solution api.ISolution) []api.ConstraintMatch {
+	if justified, ok := c.(api.IJustifiedConstraint); ok {
+		return justified.Matches(solution)
+	}
+	weight := MatchWeightOf(c, solution)
+	if weight == 0 {
+		return nil
+	}
+	return []api.ConstraintMatch{{Weight: weight}}
+}
+
+// PackageOf 获取约束所属的包，约束未实现 api.IPackagedConstraint 时为空
+func PackageOf(c api.IConstraint) string {
+	if packaged, ok := c.(api.IPackagedConstraint); ok {
+		return packaged.GetPackage()
+	}
+	return ""
+}
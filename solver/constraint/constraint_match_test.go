@@ -0,0 +1,113 @@
+package constraint
+
+import (
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	score "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+)
+
+// boolConstraint 只实现 api.IConstraint
+type boolConstraint struct {
+	matched bool
+}
+
+func (c boolConstraint) GetName() string          { return "bool" }
+func (c boolConstraint) GetScore() api.IScore     { return score.ONE_SOFT }
+func (c boolConstraint) Match(api.ISolution) bool { return c.matched }
+func (c boolConstraint) GetWeight() int           { return -1 }
+
+func TestMatchWeightOfBooleanConstraint(t *testing.T) {
+	if got := MatchWeightOf(boolConstraint{matched: true}, nil); got != 1 {
+		t.Errorf("matched: got match weight %d, want 1", got)
+	}
+	if got := MatchWeightOf(boolConstraint{}, nil); got != 0 {
+		t.Errorf("not matched: got match weight %d, want 0", got)
+	}
+}
+
+func TestMatchWeightOfWeightedConstraint(t *testing.T) {
+	c := NewConstraint(WithName("overtime"), WithMatchWeightFunc(func(api.ISolution) int { return 30 }))
+	if got := MatchWeightOf(c, nil); got != 30 {
+		t.Errorf("got match weight %d, want 30", got)
+	}
+}
+
+func TestMatchWeightOfOverriddenConstraint(t *testing.T) {
+	cm := NewConstraintManager()
+	cm.AddConstraint(NewConstraint(WithName("overtime"), WithWeight(-1), WithMatchWeightFunc(func(api.ISolution) int { return 30 })))
+	overridden := NewConstraintWeightOverrides(map[string]int{"overtime": -2}).Apply(cm).GetConstraints()[0]
+
+	if got := MatchWeightOf(overridden, nil); got != 30 {
+		t.Errorf("got match weight %d, want the match weight 30 of the wrapped constraint", got)
+	}
+	if got := overridden.GetWeight(); got != -2 {
+		t.Errorf("got weight %d, want -2", got)
+	}
+}
+
+func TestMatchWeightPrefersMatchWeightFunc(t *testing.T) {
+	matchesCalled := false
+	c := NewConstraint(
+		WithMatchWeightFunc(func(api.ISolution) int { return 3 }),
+		WithMatchesFunc(func(api.ISolution) []api.ConstraintMatch {
+			matchesCalled = true
+			return []api.ConstraintMatch{{Weight: 1}, {Weight: 2}}
+		}),
+	)
+	if got := c.MatchWeight(nil); got != 3 {
+		t.Errorf("got match weight %d, want 3", got)
+	}
+	if matchesCalled {
+		t.Error("MatchWeight built the matches although MatchWeightFunc is set")
+	}
+}
+
+func TestMatchWeightSumsMatches(t *testing.T) {
+	c := NewConstraint(WithMatchesFunc(func(api.ISolution) []api.ConstraintMatch {
+		return []api.ConstraintMatch{{Weight: 1}, {Weight: 2}}
+	}))
+	if got := c.MatchWeight(nil); got != 3 {
+		t.Errorf("got match weight %d, want 3", got)
+	}
+	if !c.Match(nil) {
+		t.Error("constraint with matches does not match")
+	}
+}
+
+func TestMatchesOfBooleanConstraint(t *testing.T) {
+	if got := MatchesOf(boolConstraint{matched: true}, nil); len(got) != 1 || got[0].Weight != 1 || got[0].Justification != nil {
+		t.Errorf("matched: got matches %v, want one match of weight 1 without justification", got)
+	}
+	if got := MatchesOf(boolConstraint{}, nil); len(got) != 0 {
+		t.Errorf("not matched: got matches %v, want none", got)
+	}
+	if got := PackageOf(boolConstraint{}); got != "" {
+		t.Errorf("got package %q, want none", got)
+	}
+}
+
+type overlap struct {
+	a, b string
+}
+
+func TestOverriddenConstraintKeepsJustificationsAndPackage(t *testing.T) {
+	cm := NewConstraintManager()
+	cm.AddConstraint(NewConstraint(
+		WithName("overlap"),
+		WithPackage("shift"),
+		WithMatchWeightFunc(func(api.ISolution) int { return 2 }),
+		WithMatchesFunc(func(api.ISolution) []api.ConstraintMatch {
+			return []api.ConstraintMatch{{Weight: 1, Justification: overlap{"a", "b"}}, {Weight: 1, Justification: overlap{"b", "c"}}}
+		}),
+	))
+	overridden := NewConstraintWeightOverrides(map[string]int{"overlap": -3}).Apply(cm).GetConstraints()[0]
+
+	matches := MatchesOf(overridden, nil)
+	if len(matches) != 2 || matches[0].Justification != (overlap{"a", "b"}) || matches[1].Justification != (overlap{"b", "c"}) {
+		t.Errorf("got matches %v, want the justified matches of the wrapped constraint", matches)
+	}
+	if got := PackageOf(overridden); got != "shift" {
+		t.Errorf("got package %q, want shift", got)
+	}
+}
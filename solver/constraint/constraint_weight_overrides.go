@@ -0,0 +1,122 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// ConstraintWeightOverrides 按约束名称覆盖约束权重，权重为 0 时约束不影响分数
+// 用于在不重新构建约束的情况下按次调整约束的优先级
+type ConstraintWeightOverrides struct {
+	weights map[string]int
+}
+
+// NewConstraintWeightOverrides 创建约束权重覆盖，weights 以约束名称为键
+func NewConstraintWeightOverrides(weights map[string]int) *ConstraintWeightOverrides {
+	o := &ConstraintWeightOverrides{weights: make(map[string]int, len(weights))}
+	for name, weight := range weights {
+		o.weights[name] = weight
+	}
+	return o
+}
+
+// ConstraintWeightOverridesProvider 可选接口，解决方案携带约束权重覆盖时实现
+type ConstraintWeightOverridesProvider interface {
+	GetConstraintWeightOverrides() *ConstraintWeightOverrides
+}
+
+// SetWeight 设置约束的权重
+func (o *ConstraintWeightOverrides) SetWeight(name string, weight int) {
+	o.weights[name] = weight
+}
+
+// GetWeight 获取约束被覆盖的权重，未覆盖时返回 false
+func (o *ConstraintWeightOverrides) GetWeight(name string) (int, bool) {
+	weight, ok := o.weights[name]
+	return weight, ok
+}
+
+// GetConstraintNames 获取被覆盖的约束名称，按字母顺序排列
+func (o *ConstraintWeightOverrides) GetConstraintNames() []string {
+	names := make([]string, 0, len(o.weights))
+	for name := range o.weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate 检查被覆盖的约束都已在约束配置中注册
+func (o *ConstraintWeightOverrides) Validate(constraintManager api.IConstraintConfigure) error {
+	registered := make(map[string]struct{})
+	for _, c := range constraintManager.GetConstraints() {
+		registered[c.GetName()] = struct{}{}
+	}
+	var errs []error
+	for _, name := range o.GetConstraintNames() {
+		if _, ok := registered[name]; !ok {
+			errs = append(errs, fmt.Errorf("constraint weight override for unknown constraint %q", name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply 返回覆盖了权重的约束配置，不修改原约束
+// 返回值在调用时确定约束与权重，之后对覆盖或原约束配置的修改不影响返回值
+func (o *ConstraintWeightOverrides) Apply(constraintManager api.IConstraintConfigure) api.IConstraintConfigure {
+	constraints := constraintManager.GetConstraints()
+	overridden := make([]api.IConstraint, len(constraints))
+	for i, c := range constraints {
+		if weight, ok := o.GetWeight(c.GetName()); ok {
+			overridden[i] = &overriddenConstraint{IConstraint: c, weight: weight}
+		} else {
+			overridden[i] = c
+		}
+	}
+	return &overriddenConstraintManager{constraints: overridden}
+}
+
+// overriddenConstraintManager 每次计算分数都会获取约束，约束只在 Apply 时包装一次
+type overriddenConstraintManager struct {
+	constraints []api.IConstraint
+}
+
+func (m *overriddenConstraintManager) GetConstraints() []api.IConstraint {
+	return m.constraints
+}
+
+type overriddenConstraint struct {
+	api.IConstraint
+	weight int
+}
+
+func (c *overriddenConstraint) GetWeight() int {
+	return c.weight
+}
+
+// 转发被包装约束的可选接口，包装后仍按匹配权重计算分数，分数明细保留匹配说明与包
+
+func (c *overriddenConstraint) MatchWeight(solution api.ISolution) int {
+	return MatchWeightOf(c.IConstraint, solution)
+}
+
+func (c *overriddenConstraint) Matches(solution api.ISolution) []api.ConstraintMatch {
+	return MatchesOf(c.IConstraint, solution)
+}
+
+func (c *overriddenConstraint) GetPackage() string {
+	return PackageOf(c.IConstraint)
+}
+
+// ForSolution 返回应用了解决方案携带的约束权重覆盖的约束配置，解决方案没有覆盖时返回原约束配置
+func ForSolution(constraintManager api.IConstraintConfigure, solution api.ISolution) api.IConstraintConfigure {
+	if provider, ok := solution.(ConstraintWeightOverridesProvider); ok {
+		if overrides := provider.GetConstraintWeightOverrides(); overrides != nil {
+			return overrides.Apply(constraintManager)
+		}
+	}
+	return constraintManager
+}
@@ -0,0 +1,59 @@
+package constraint
+
+import (
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+func newTestConstraintManager() *ConstraintManager {
+	cm := NewConstraintManager()
+	cm.AddConstraint(NewConstraint(WithName("a"), WithWeight(-1), WithType(HARD), WithMatchWeightFunc(func(api.ISolution) int { return 1 })))
+	cm.AddConstraint(NewConstraint(WithName("b"), WithWeight(-2), WithType(SOFT), WithMatchWeightFunc(func(api.ISolution) int { return 1 })))
+	return cm
+}
+
+func weights(cm api.IConstraintConfigure) map[string]int {
+	result := make(map[string]int)
+	for _, c := range cm.GetConstraints() {
+		result[c.GetName()] = c.GetWeight()
+	}
+	return result
+}
+
+func TestApplyOverridesWeights(t *testing.T) {
+	cm := newTestConstraintManager()
+	overridden := NewConstraintWeightOverrides(map[string]int{"b": 0}).Apply(cm)
+
+	if got := weights(overridden); got["a"] != -1 || got["b"] != 0 {
+		t.Errorf("got weights %v, want a -1 and b 0", got)
+	}
+	if got := weights(cm); got["b"] != -2 {
+		t.Errorf("original weight of b changed to %d", got["b"])
+	}
+}
+
+func TestApplyIsUnaffectedByLaterChanges(t *testing.T) {
+	cm := newTestConstraintManager()
+	overrides := NewConstraintWeightOverrides(map[string]int{"a": -5})
+	overridden := overrides.Apply(cm)
+
+	overrides.SetWeight("a", -7)
+	overrides.SetWeight("b", -9)
+	cm.AddConstraint(NewConstraint(WithName("c"), WithWeight(-3), WithType(SOFT)))
+
+	if got := weights(overridden); len(got) != 2 || got["a"] != -5 || got["b"] != -2 {
+		t.Errorf("got weights %v, want the weights when Apply was called", got)
+	}
+}
+
+func TestApplyWrapsConstraintsOnce(t *testing.T) {
+	overridden := NewConstraintWeightOverrides(map[string]int{"a": -5}).Apply(newTestConstraintManager())
+
+	first, second := overridden.GetConstraints(), overridden.GetConstraints()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("constraint %s was wrapped again", first[i].GetName())
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package constrainttest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+)
+
+// ConstraintVerifier 在给定的事实与实体上验证单个约束或所有约束的分数，用于约束的单元测试
+// 断言失败时返回错误，测试中的用法：
+//
+//	verifier := constrainttest.NewConstraintVerifier(constraintManager)
+//	err := verifier.VerifyThat("room conflict").Given(rooms, lesson1, lesson2).Penalizes(1)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+type ConstraintVerifier struct {
+	constraintManager api.IConstraintConfigure
+}
+
+// NewConstraintVerifier 创建约束验证器，constraintManager 通常为 *constraint.ConstraintManager
+func NewConstraintVerifier(constraintManager api.IConstraintConfigure) *ConstraintVerifier {
+	return &ConstraintVerifier{constraintManager: constraintManager}
+}
+
+// VerifyThat 验证指定名称的单个约束
+func (v *ConstraintVerifier) VerifyThat(constraintName string) *SingleConstraintVerification {
+	return &SingleConstraintVerification{verifier: v, constraintName: constraintName}
+}
+
+// VerifyThatAll 验证所有约束共同计算的分数
+func (v *ConstraintVerifier) VerifyThatAll() *MultiConstraintVerification {
+	return &MultiConstraintVerification{verifier: v}
+}
+
+// SingleConstraintVerification 单个约束的验证，通过 Given 或 GivenSolution 提供输入
+type SingleConstraintVerification struct {
+	verifier       *ConstraintVerifier
+	constraintName string
+}
+
+// Given 以问题事实与规划实体构造解决方案
+// 约束的匹配函数需要断言具体的解决方案类型时使用 GivenSolution
+func (v *SingleConstraintVerification) Given(facts []interface{}, entities ...api.IPlanningEntity) *SingleConstraintAssertion {
+	return v.GivenSolution(newGivenSolution(facts, entities))
+}
+
+// GivenSolution 使用完整的解决方案
+func (v *SingleConstraintVerification) GivenSolution(solution api.ISolution) *SingleConstraintAssertion {
+	assertion := &SingleConstraintAssertion{constraintName: v.constraintName}
+	var target api.IConstraint
+	for _, c := range v.verifier.constraintManager.GetConstraints() {
+		if c.GetName() == v.constraintName {
+			target = c
+			break
+		}
+	}
+	if target == nil {
+		assertion.err = fmt.Errorf("constraint %q is not registered, registered constraints: %s",
+			v.constraintName, strings.Join(constraintNames(v.verifier.constraintManager), ", "))
+		return assertion
+	}
+	assertion.weight = target.GetWeight()
+	assertion.matchCount = len(constraint.MatchesOf(target, solution))
+	// 只包含该约束的分数，不计未初始化变量
+	assertion.impact = score.NewScoreCalculator(singleConstraint{target}).Calculate(solution).WithInitScore(0)
+	return assertion
+}
+
+// SingleConstraintAssertion 单个约束在给定输入上的断言
+type SingleConstraintAssertion struct {
+	constraintName string
+	weight         int
+	matchCount     int
+	// 约束对分数的影响
+	impact api.IScore
+	// 查找约束失败的原因
+	err error
+}
+
+// Penalizes 断言约束以惩罚方式匹配了 times 次
+func (a *SingleConstraintAssertion) Penalizes(times int) error {
+	if a.err != nil {
+		return a.err
+	}
+	if times > 0 && a.weight >= 0 {
+		return a.errorf("expected to penalize %d time(s), but weight %d does not penalize", times, a.weight)
+	}
+	if a.matchCount != times {
+		return a.errorf("expected to penalize %d time(s), got %d match(es)", times, a.matchCount)
+	}
+	return nil
+}
+
+// PenalizesBy 断言约束使分数降低了 penalty（约束权重乘以匹配权重），penalty 为正数，如 1 个硬分数
+func (a *SingleConstraintAssertion) PenalizesBy(penalty api.IScore) error {
+	if a.err != nil {
+		return a.err
+	}
+	if c, err := scoredef.Compare(a.impact, penalty.Negate()); err != nil {
+		return a.errorf("%v", err)
+	} else if c != 0 {
+		return a.errorf("expected to penalize by %s, got impact %s", penalty.ToShortString(), a.impact.ToShortString())
+	}
+	return nil
+}
+
+// Rewards 断言约束以奖励方式匹配了 times 次
+func (a *SingleConstraintAssertion) Rewards(times int) error {
+	if a.err != nil {
+		return a.err
+	}
+	if times > 0 && a.weight <= 0 {
+		return a.errorf("expected to reward %d time(s), but weight %d does not reward", times, a.weight)
+	}
+	if a.matchCount != times {
+		return a.errorf("expected to reward %d time(s), got %d match(es)", times, a.matchCount)
+	}
+	return nil
+}
+
+// RewardsBy 断言约束使分数提高了 reward
+func (a *SingleConstraintAssertion) RewardsBy(reward api.IScore) error {
+	if a.err != nil {
+		return a.err
+	}
+	if c, err := scoredef.Compare(a.impact, reward); err != nil {
+		return a.errorf("%v", err)
+	} else if c != 0 {
+		return a.errorf("expected to reward by %s, got impact %s", reward.ToShortString(), a.impact.ToShortString())
+	}
+	return nil
+}
+
+// HasNoMatches 断言约束没有匹配
+func (a *SingleConstraintAssertion) HasNoMatches() error {
+	if a.err != nil {
+		return a.err
+	}
+	if a.matchCount != 0 {
+		return a.errorf("expected no matches, got %d match(es) with impact %s", a.matchCount, a.impact.ToShortString())
+	}
+	return nil
+}
+
+func (a *SingleConstraintAssertion) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("constraint %q: %s", a.constraintName, fmt.Sprintf(format, args...))
+}
+
+// MultiConstraintVerification 所有约束的验证，通过 Given 或 GivenSolution 提供输入
+type MultiConstraintVerification struct {
+	verifier *ConstraintVerifier
+}
+
+// Given 以问题事实与规划实体构造解决方案
+func (v *MultiConstraintVerification) Given(facts []interface{}, entities ...api.IPlanningEntity) *MultiConstraintAssertion {
+	return v.GivenSolution(newGivenSolution(facts, entities))
+}
+
+// GivenSolution 使用完整的解决方案
+func (v *MultiConstraintVerification) GivenSolution(solution api.ISolution) *MultiConstraintAssertion {
+	explanation, err := score.ExplainScore(v.verifier.constraintManager, solution)
+	return &MultiConstraintAssertion{explanation: explanation, err: err}
+}
+
+// MultiConstraintAssertion 所有约束在给定输入上的断言
+type MultiConstraintAssertion struct {
+	explanation *score.ScoreExplanation
+	// 计算分数时的错误，如约束的分数类型不一致，所有断言都返回该错误
+	err error
+}
+
+// Scores 断言所有约束共同计算的分数（包括初始化分数）等于 expected，失败时附带分数明细
+func (a *MultiConstraintAssertion) Scores(expected api.IScore) error {
+	if a.err != nil {
+		return a.err
+	}
+	c, err := scoredef.Compare(a.explanation.Score, expected)
+	if err != nil {
+		return err
+	}
+	if c != 0 {
+		return fmt.Errorf("expected score %s, got %s\n%s", expected.ToShortString(), a.explanation.Score.ToShortString(), strings.TrimSuffix(a.explanation.Summary(), "\n"))
+	}
+	return nil
+}
+
+// HasNoMatches 断言没有任何约束匹配
+func (a *MultiConstraintAssertion) HasNoMatches() error {
+	if a.err != nil {
+		return a.err
+	}
+	var matched []string
+	for _, c := range a.explanation.Constraints {
+		if c.Matched {
+			matched = append(matched, c.ConstraintName)
+		}
+	}
+	if len(matched) > 0 {
+		return fmt.Errorf("expected no matches, got matches for: %s", strings.Join(matched, ", "))
+	}
+	return nil
+}
+
+// singleConstraint 只包含一个约束的约束配置
+type singleConstraint struct {
+	constraint api.IConstraint
+}
+
+func (s singleConstraint) GetConstraints() []api.IConstraint {
+	return []api.IConstraint{s.constraint}
+}
+
+func constraintNames(constraintManager api.IConstraintConfigure) []string {
+	constraints := constraintManager.GetConstraints()
+	names := make([]string, 0, len(constraints))
+	for _, c := range constraints {
+		names = append(names, c.GetName())
+	}
+	return names
+}
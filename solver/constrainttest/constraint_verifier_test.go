@@ -0,0 +1,217 @@
+package constrainttest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	score "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+)
+
+type roomVariable struct {
+	room interface{}
+}
+
+func (v *roomVariable) GetValue() interface{}          { return v.room }
+func (v *roomVariable) SetValue(value interface{})     { v.room = value }
+func (v *roomVariable) GetValueRange() api.IValueRange { return nil }
+
+type lesson struct {
+	name    string
+	minutes int
+	room    *roomVariable
+}
+
+func (l *lesson) PlanningFilter() {}
+func (l *lesson) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{l.room}
+}
+
+func newLesson(name string, minutes int, room interface{}) *lesson {
+	return &lesson{name: name, minutes: minutes, room: &roomVariable{room: room}}
+}
+
+func lessons(solution api.ISolution) []*lesson {
+	result := make([]*lesson, 0, len(solution.GetPlanningEntities()))
+	for _, entity := range solution.GetPlanningEntities() {
+		result = append(result, entity.(*lesson))
+	}
+	return result
+}
+
+type roomConflict struct {
+	a, b string
+}
+
+// newConstraintManager 同一房间的两节课惩罚 1 个硬分数，超过 60 分钟的每分钟惩罚 1 个软分数，使用房间 A 的每节课奖励 2 个软分数
+func newConstraintManager() *constraint.ConstraintManager {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("room conflict"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.HARD),
+		constraint.WithMatchesFunc(func(solution api.ISolution) []api.ConstraintMatch {
+			var matches []api.ConstraintMatch
+			ls := lessons(solution)
+			for i := range ls {
+				for j := i + 1; j < len(ls); j++ {
+					if ls[i].room.room != nil && ls[i].room.room == ls[j].room.room {
+						matches = append(matches, api.ConstraintMatch{Weight: 1, Justification: roomConflict{ls[i].name, ls[j].name}})
+					}
+				}
+			}
+			return matches
+		}),
+	))
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("overtime"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchWeightFunc(func(solution api.ISolution) int {
+			total := 0
+			for _, l := range lessons(solution) {
+				total += max(0, l.minutes-60)
+			}
+			return total
+		}),
+	))
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("preferred room"),
+		constraint.WithWeight(2),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchesFunc(func(solution api.ISolution) []api.ConstraintMatch {
+			var matches []api.ConstraintMatch
+			for _, l := range lessons(solution) {
+				if l.room.room == "A" {
+					matches = append(matches, api.ConstraintMatch{Weight: 1, Justification: l.name})
+				}
+			}
+			return matches
+		}),
+	))
+	return cm
+}
+
+func assertError(t *testing.T, err error, want string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("got no error, want %q", want)
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("got error %q, want it to contain %q", err, want)
+	}
+}
+
+func TestPenalizes(t *testing.T) {
+	verifier := NewConstraintVerifier(newConstraintManager())
+	given := verifier.VerifyThat("room conflict").Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "A"), newLesson("music", 60, "B"))
+
+	if err := given.Penalizes(1); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	assertError(t, given.Penalizes(2), `constraint "room conflict": expected to penalize 2 time(s), got 1 match(es)`)
+
+	reward := verifier.VerifyThat("preferred room").Given(nil, newLesson("math", 60, "A"))
+	assertError(t, reward.Penalizes(1), `constraint "preferred room": expected to penalize 1 time(s), but weight 2 does not penalize`)
+}
+
+func TestPenalizesBy(t *testing.T) {
+	given := NewConstraintVerifier(newConstraintManager()).VerifyThat("overtime").Given(nil, newLesson("math", 90, "A"), newLesson("art", 75, "B"))
+
+	if err := given.PenalizesBy(score.NewHardSoftScore(0, 0, 45)); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	assertError(t, given.PenalizesBy(score.NewHardSoftScore(0, 0, 30)),
+		`constraint "overtime": expected to penalize by HardSoftScore[initScore=0, hardScore=0, softScore=30], got impact HardSoftScore[initScore=0, hardScore=0, softScore=-45]`)
+	assertError(t, given.PenalizesBy(simple.NewSimpleScore(0, 45)), "score type mismatch")
+}
+
+func TestRewards(t *testing.T) {
+	verifier := NewConstraintVerifier(newConstraintManager())
+	given := verifier.VerifyThat("preferred room").Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "A"), newLesson("music", 60, "B"))
+
+	if err := given.Rewards(2); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	if err := given.RewardsBy(score.NewHardSoftScore(0, 0, 4)); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	assertError(t, given.Rewards(3), `constraint "preferred room": expected to reward 3 time(s), got 2 match(es)`)
+	assertError(t, given.RewardsBy(score.NewHardSoftScore(0, 0, 2)),
+		`constraint "preferred room": expected to reward by HardSoftScore[initScore=0, hardScore=0, softScore=2], got impact HardSoftScore[initScore=0, hardScore=0, softScore=4]`)
+
+	penalty := verifier.VerifyThat("room conflict").Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "A"))
+	assertError(t, penalty.Rewards(1), `constraint "room conflict": expected to reward 1 time(s), but weight -1 does not reward`)
+}
+
+func TestHasNoMatches(t *testing.T) {
+	verifier := NewConstraintVerifier(newConstraintManager())
+
+	if err := verifier.VerifyThat("room conflict").Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "B")).HasNoMatches(); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	// 未分配房间的课不冲突
+	if err := verifier.VerifyThat("room conflict").Given(nil, newLesson("math", 60, nil), newLesson("art", 60, nil)).HasNoMatches(); err != nil {
+		t.Errorf("unassigned lessons: got error %v", err)
+	}
+	conflict := verifier.VerifyThat("room conflict").Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "A"))
+	assertError(t, conflict.HasNoMatches(),
+		`constraint "room conflict": expected no matches, got 1 match(es) with impact HardSoftScore[initScore=0, hardScore=-1, softScore=0]`)
+}
+
+func TestUnknownConstraint(t *testing.T) {
+	given := NewConstraintVerifier(newConstraintManager()).VerifyThat("teacher conflict").Given(nil, newLesson("math", 60, "A"))
+
+	want := `constraint "teacher conflict" is not registered, registered constraints: room conflict, overtime, preferred room`
+	for _, err := range []error{
+		given.Penalizes(1),
+		given.PenalizesBy(score.ONE_HARD),
+		given.Rewards(1),
+		given.RewardsBy(score.ONE_SOFT),
+		given.HasNoMatches(),
+	} {
+		assertError(t, err, want)
+	}
+}
+
+func TestScores(t *testing.T) {
+	verifier := NewConstraintVerifier(newConstraintManager())
+	// 冲突 -1 硬分数，加班 -30 软分数，两节课在房间 A 奖励 4 软分数，一节课未分配房间
+	given := verifier.VerifyThatAll().Given(nil, newLesson("math", 90, "A"), newLesson("art", 60, "A"), newLesson("music", 60, nil))
+
+	if err := given.Scores(score.NewHardSoftScore(-1, -1, -26)); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	err := given.Scores(score.NewHardSoftScore(0, 0, 0))
+	assertError(t, err, "expected score HardSoftScore[initScore=0, hardScore=0, softScore=0], got HardSoftScore[initScore=-1, hardScore=-1, softScore=-26]")
+	// 失败时附带分数明细
+	assertError(t, err, "room conflict (weight -1): matched 1 time(s), match weight 1")
+	assertError(t, err, "overtime (weight -1): matched 1 time(s), match weight 30")
+	assertError(t, given.Scores(simple.NewSimpleScore(0, 0)), "score type mismatch")
+}
+
+func TestAllHasNoMatches(t *testing.T) {
+	verifier := NewConstraintVerifier(newConstraintManager())
+
+	if err := verifier.VerifyThatAll().Given(nil, newLesson("math", 60, "B"), newLesson("art", 60, "C")).HasNoMatches(); err != nil {
+		t.Errorf("got error %v", err)
+	}
+	matched := verifier.VerifyThatAll().Given(nil, newLesson("math", 60, "A"), newLesson("art", 60, "A"))
+	assertError(t, matched.HasNoMatches(), "expected no matches, got matches for: room conflict, preferred room")
+}
+
+func TestAllScoreTypeMismatch(t *testing.T) {
+	cm := newConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("simple"),
+		constraint.WithWeight(-1),
+		constraint.WithScore(simple.ONE),
+		constraint.WithMatchFunc(func(api.ISolution) bool { return true }),
+	))
+	given := NewConstraintVerifier(cm).VerifyThatAll().Given(nil, newLesson("math", 60, "A"))
+
+	assertError(t, given.Scores(score.ZERO), "score type mismatch")
+	assertError(t, given.HasNoMatches(), "score type mismatch")
+}
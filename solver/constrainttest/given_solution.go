@@ -0,0 +1,38 @@
+package constrainttest
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// givenSolution 由 Given 的问题事实与规划实体构造的解决方案
+type givenSolution struct {
+	score    api.IScore
+	facts    []interface{}
+	entities []api.IPlanningEntity
+}
+
+func newGivenSolution(facts []interface{}, entities []api.IPlanningEntity) *givenSolution {
+	return &givenSolution{facts: facts, entities: entities}
+}
+
+func (s *givenSolution) GetScore() api.IScore {
+	return s.score
+}
+
+func (s *givenSolution) SetScore(score api.IScore) {
+	s.score = score
+}
+
+func (s *givenSolution) GetPlanningEntities() []api.IPlanningEntity {
+	return s.entities
+}
+
+func (s *givenSolution) SetPlanningEntities(entities []api.IPlanningEntity) {
+	s.entities = entities
+}
+
+func (s *givenSolution) GetProblemFacts() []interface{} {
+	return s.facts
+}
+
+func (s *givenSolution) SetProblemFacts(facts []interface{}) {
+	s.facts = facts
+}
@@ -0,0 +1,385 @@
+package heuristic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/termination"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+// 构造启发式 为未初始化的规划变量赋值，生成初始解
+type ConstructionHeuristic struct {
+	config        *config.ConstructionHeuristicConfig
+	scoreDirector api.IScoreDirector
+	ctx           context.Context
+	// 外部终止检查（如求解器被停止）
+	terminated func() bool
+	// 阶段终止条件
+	termination termination.Termination
+	scope       *termination.Scope
+	// 只为这些实体赋值，为 nil 时为所有可移动实体赋值
+	entities []api.IPlanningEntity
+
+	lastStepScore api.IScore
+}
+
+// 待赋值的规划变量
+type placement struct {
+	entity   api.IPlanningEntity
+	variable api.IPlanningVariable
+}
+
+func NewConstructionHeuristic(cfg *config.ConstructionHeuristicConfig, scoreDirector api.IScoreDirector) *ConstructionHeuristic {
+	return &ConstructionHeuristic{
+		config:        cfg,
+		scoreDirector: scoreDirector,
+		ctx:           context.Background(),
+		termination:   termination.Build(cfg.Termination),
+		scope:         termination.NewScope(),
+	}
+}
+
+// SetTerminationFunc 设置外部终止检查
+func (h *ConstructionHeuristic) SetTerminationFunc(terminated func() bool) {
+	h.terminated = terminated
+}
+
+// SetParentScope 设置上级运行状态，构造阶段的步数与移动数会累计到上级
+func (h *ConstructionHeuristic) SetParentScope(parent *termination.Scope) {
+	h.scope = termination.NewChildScope(parent)
+}
+
+// SetEntities 限制只为指定的实体赋值，用于重建被破坏的实体
+func (h *ConstructionHeuristic) SetEntities(entities []api.IPlanningEntity) {
+	h.entities = entities
+}
+
+// GetStepCount 获取已执行的步数
+func (h *ConstructionHeuristic) GetStepCount() int {
+	return h.scope.GetStepCount()
+}
+
+// Construct 构造初始解，上下文取消时停止并保留已完成的赋值
+// 未设置类型时使用 FIRST_FIT，未知的类型返回错误且不修改解决方案
+func (h *ConstructionHeuristic) Construct(ctx context.Context, problem api.ISolution) (api.ISolution, error) {
+	h.ctx = ctx
+	h.lastStepScore = h.scoreDirector.Calculate(problem)
+	h.scope.Start(h.lastStepScore)
+
+	switch h.config.Type {
+	case "", config.ConstructionHeuristicTypeFirstFit:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerNone, config.ValueSorterMannerNone)
+	case config.ConstructionHeuristicTypeFirstFitDecreasing:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerDecreasingDifficulty, config.ValueSorterMannerNone)
+	case config.ConstructionHeuristicTypeWeakestFit:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerNone, config.ValueSorterMannerIncreasingStrength)
+	case config.ConstructionHeuristicTypeWeakestFitDecreasing:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerDecreasingDifficulty, config.ValueSorterMannerIncreasingStrength)
+	case config.ConstructionHeuristicTypeStrongestFit:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerNone, config.ValueSorterMannerDecreasingStrength)
+	case config.ConstructionHeuristicTypeStrongestFitDecreasing:
+		h.allocateEntityFromQueue(problem, config.EntitySorterMannerDecreasingDifficulty, config.ValueSorterMannerDecreasingStrength)
+	case config.ConstructionHeuristicTypeAllocateEntityFromQueue:
+		h.allocateEntityFromQueue(problem, h.entitySorterManner(), h.config.ValueSorterManner)
+	case config.ConstructionHeuristicTypeAllocateToValueFromQueue:
+		h.allocateToValueFromQueue(problem, h.entitySorterManner(), h.config.ValueSorterManner)
+	case config.ConstructionHeuristicTypeCheapestInsertion:
+		h.cheapestInsertion(problem, h.entitySorterManner(), h.config.ValueSorterManner)
+	default:
+		return problem, fmt.Errorf("unknown construction heuristic type %q", h.config.Type)
+	}
+	return problem, nil
+}
+
+// allocateEntityFromQueue 按实体队列依次为每个变量选取最佳值
+// FIRST_FIT、WEAKEST_FIT、STRONGEST_FIT 及其递减变体都是它的特例
+func (h *ConstructionHeuristic) allocateEntityFromQueue(problem api.ISolution, entityManner, valueManner string) {
+	for _, p := range h.getPlacements(problem, entityManner) {
+		if h.isTerminated() {
+			return
+		}
+		values := h.getValues(p, valueManner)
+		bestValue, bestScore, ok := h.pickBestValue(problem, p.variable, values)
+		if !ok {
+			continue
+		}
+		h.doStep(p.variable, bestValue, bestScore)
+	}
+}
+
+// allocateToValueFromQueue 按值队列依次为每个值选取最佳的变量，直到所有变量都已赋值
+// 可为空的变量在没有可接受的值时保持未赋值
+func (h *ConstructionHeuristic) allocateToValueFromQueue(problem api.ISolution, entityManner, valueManner string) {
+	pending := h.getPlacements(problem, entityManner)
+	valueQueue := make([]interface{}, 0)
+	for _, p := range pending {
+		for _, value := range h.getValues(p, valueManner) {
+			if !containsValue(valueQueue, value) {
+				valueQueue = append(valueQueue, value)
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		progressed := false
+		for _, value := range valueQueue {
+			if len(pending) == 0 {
+				break
+			}
+			if h.isTerminated() {
+				return
+			}
+			bestIndex := -1
+			var bestScore api.IScore
+			for i, p := range pending {
+				if !valuerange.Contains(valuerange.Of(p.entity, p.variable), value) {
+					continue
+				}
+				score := h.evaluateAssignment(problem, p.variable, value)
+				if score == nil {
+					return
+				}
+				// 可为空的变量只接受不劣于保持未赋值的值
+				if solution.IsNullable(p.variable) && score.CompareTo(h.lastStepScore) < 0 {
+					continue
+				}
+				if bestIndex < 0 || score.CompareTo(bestScore) > 0 {
+					bestIndex = i
+					bestScore = score
+				}
+			}
+			if bestIndex < 0 {
+				continue
+			}
+			h.doStep(pending[bestIndex].variable, value, bestScore)
+			pending = append(pending[:bestIndex], pending[bestIndex+1:]...)
+			progressed = true
+		}
+		if !progressed {
+			return
+		}
+	}
+}
+
+// cheapestInsertion 每一步评估所有未赋值变量与所有值的组合，选取全局最佳的赋值
+func (h *ConstructionHeuristic) cheapestInsertion(problem api.ISolution, entityManner, valueManner string) {
+	pending := h.getPlacements(problem, entityManner)
+	for len(pending) > 0 {
+		if h.isTerminated() {
+			return
+		}
+		bestIndex := -1
+		var bestValue interface{}
+		var bestScore api.IScore
+		for i, p := range pending {
+			value, score, ok := h.pickBestValue(problem, p.variable, h.getValues(p, valueManner))
+			if !ok {
+				continue
+			}
+			if bestIndex < 0 || score.CompareTo(bestScore) > 0 {
+				bestIndex = i
+				bestValue = value
+				bestScore = score
+			}
+		}
+		if bestIndex < 0 {
+			return
+		}
+		h.doStep(pending[bestIndex].variable, bestValue, bestScore)
+		pending = append(pending[:bestIndex], pending[bestIndex+1:]...)
+	}
+}
+
+// pickBestValue 评估变量的候选值，返回最佳值
+// 可为空的变量最后评估空值，没有值严格优于空值时（包括分数与空值相同）保持未赋值
+func (h *ConstructionHeuristic) pickBestValue(problem api.ISolution, variable api.IPlanningVariable, values []interface{}) (interface{}, api.IScore, bool) {
+	if solution.IsNullable(variable) {
+		values = append(values[:len(values):len(values)], nil)
+	}
+	var bestValue interface{}
+	var bestScore api.IScore
+	found := false
+	for _, value := range values {
+		score := h.evaluateAssignment(problem, variable, value)
+		if score == nil {
+			return nil, nil, false
+		}
+		if !found || isBetterValue(value, score, bestScore) {
+			bestValue = value
+			bestScore = score
+			found = true
+		}
+		if h.isPickEarly(score) {
+			break
+		}
+	}
+	return bestValue, bestScore, found
+}
+
+// isBetterValue 值的分数是否优于当前最佳，空值与当前最佳分数相同时也视为更优
+func isBetterValue(value interface{}, score, bestScore api.IScore) bool {
+	c := score.CompareTo(bestScore)
+	return c > 0 || (c == 0 && value == nil)
+}
+
+// isPickEarly 是否不再评估剩余的值
+func (h *ConstructionHeuristic) isPickEarly(score api.IScore) bool {
+	switch h.config.PickEarlyType {
+	case config.PickEarlyTypeFirstNonDeterioratingScore:
+		return score.CompareTo(h.lastStepScore) >= 0
+	case config.PickEarlyTypeFirstFeasibleScore:
+		return score.IsFeasible()
+	default:
+		return false
+	}
+}
+
+// doStep 执行一步赋值
+func (h *ConstructionHeuristic) doStep(variable api.IPlanningVariable, value interface{}, score api.IScore) {
+	h.scoreDirector.BeforeVariableChanged(variable)
+	variable.SetValue(value)
+	h.scoreDirector.AfterVariableChanged(variable)
+	h.lastStepScore = score
+	h.scope.StepEnded(score)
+}
+
+// evaluateAssignment 评估单个赋值的效果，上下文取消时返回 nil
+func (h *ConstructionHeuristic) evaluateAssignment(problem api.ISolution, variable api.IPlanningVariable, value interface{}) api.IScore {
+	// 保存原始值
+	originalValue := variable.GetValue()
+
+	// 尝试新值
+	h.scoreDirector.BeforeVariableChanged(variable)
+	variable.SetValue(value)
+	h.scoreDirector.AfterVariableChanged(variable)
+
+	// 计算得分
+	newScore, err := score.CalculateContext(h.ctx, h.scoreDirector, problem)
+	h.scope.MoveEvaluated()
+
+	// 恢复原始值
+	h.scoreDirector.BeforeVariableChanged(variable)
+	variable.SetValue(originalValue)
+	h.scoreDirector.AfterVariableChanged(variable)
+
+	if err != nil {
+		return nil
+	}
+	return newScore
+}
+
+// isTerminated 检查构造阶段是否应当终止
+func (h *ConstructionHeuristic) isTerminated() bool {
+	if h.ctx.Err() != nil {
+		return true
+	}
+	if h.terminated != nil && h.terminated() {
+		return true
+	}
+	return h.termination != nil && h.termination.IsTerminated(h.scope)
+}
+
+func (h *ConstructionHeuristic) entitySorterManner() string {
+	if h.config.EntitySorterManner == "" {
+		return config.EntitySorterMannerDecreasingDifficulty
+	}
+	return h.config.EntitySorterManner
+}
+
+// getPlacements 获取所有未固定实体中未初始化的规划变量，按实体排序方式排序
+func (h *ConstructionHeuristic) getPlacements(problem api.ISolution, entityManner string) []placement {
+	entities := solution.GetMovablePlanningEntities(problem)
+	if h.entities != nil {
+		entities = make([]api.IPlanningEntity, 0, len(h.entities))
+		for _, entity := range h.entities {
+			if !solution.IsPinned(problem, entity) {
+				entities = append(entities, entity)
+			}
+		}
+	}
+	if entityManner == config.EntitySorterMannerDecreasingDifficulty {
+		sortEntitiesByDecreasingDifficulty(entities)
+	}
+	placements := make([]placement, 0, len(entities))
+	for _, entity := range entities {
+		for _, variable := range entity.GetPlanningVariables() {
+			if variable.GetValue() != nil {
+				continue
+			}
+			placements = append(placements, placement{entity: entity, variable: variable})
+		}
+	}
+	return placements
+}
+
+func (h *ConstructionHeuristic) getValues(p placement, valueManner string) []interface{} {
+	return getValues(p, valueManner)
+}
+
+// getValues 获取变量的值域（支持实体特定的值范围），按值排序方式排序
+func getValues(p placement, valueManner string) []interface{} {
+	values := valuerange.ToSlice(valuerange.Of(p.entity, p.variable))
+	switch valueManner {
+	case config.ValueSorterMannerIncreasingStrength:
+		sortValuesByStrength(p.variable, values, false)
+	case config.ValueSorterMannerDecreasingStrength:
+		sortValuesByStrength(p.variable, values, true)
+	}
+	return values
+}
+
+// sortEntitiesByDecreasingDifficulty 按难度递减排序实体
+// 实体提供难度比较器时使用比较器，否则以规划变量数量作为难度
+func sortEntitiesByDecreasingDifficulty(entities []api.IPlanningEntity) {
+	var comparator api.IComparator[api.IPlanningEntity]
+	for _, entity := range entities {
+		if annotated, ok := entity.(interface {
+			DifficultyComparatorClass() api.IComparator[api.IPlanningEntity]
+		}); ok {
+			comparator = annotated.DifficultyComparatorClass()
+			break
+		}
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		if comparator != nil {
+			return comparator.Compare(entities[i], entities[j]) > 0
+		}
+		return len(entities[i].GetPlanningVariables()) > len(entities[j].GetPlanningVariables())
+	})
+}
+
+// sortValuesByStrength 按强度排序值
+// 变量提供强度比较器时使用比较器，否则认为值域顺序即强度递增顺序
+func sortValuesByStrength(variable api.IPlanningVariable, values []interface{}, decreasing bool) {
+	annotated, ok := variable.(api.PlanningVariable)
+	if !ok || annotated.StrengthComparatorClass() == nil {
+		if decreasing {
+			for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+				values[i], values[j] = values[j], values[i]
+			}
+		}
+		return
+	}
+	comparator := annotated.StrengthComparatorClass()
+	sort.SliceStable(values, func(i, j int) bool {
+		if decreasing {
+			return comparator.Compare(values[i], values[j]) > 0
+		}
+		return comparator.Compare(values[i], values[j]) < 0
+	})
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if solution.IsSameValue(v, value) {
+			return true
+		}
+	}
+	return false
+}
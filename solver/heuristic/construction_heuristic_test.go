@@ -0,0 +1,226 @@
+package heuristic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+type intComparator struct{}
+
+func (intComparator) Compare(a, b interface{}) int { return a.(int) - b.(int) }
+
+// strengthVariable 值的强度即整数大小，与值域顺序无关
+type strengthVariable struct {
+	testVariable
+}
+
+func (v *strengthVariable) StrengthComparatorClass() api.IComparator[interface{}] {
+	return intComparator{}
+}
+
+type difficultyComparator struct{}
+
+func (difficultyComparator) Compare(a, b api.IPlanningEntity) int {
+	return a.(*difficultyEntity).difficulty - b.(*difficultyEntity).difficulty
+}
+
+type difficultyEntity struct {
+	difficulty int
+	variable   api.IPlanningVariable
+}
+
+func (e *difficultyEntity) PlanningFilter() {}
+func (e *difficultyEntity) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{e.variable}
+}
+func (e *difficultyEntity) DifficultyComparatorClass() api.IComparator[api.IPlanningEntity] {
+	return difficultyComparator{}
+}
+
+// assignmentInstance 每个值只能分配给一个实体，重复时每对实体惩罚 1 个硬分数
+// 实体 i 取值 v 时奖励 rewards[i][v] 个软分数，rewards 为 nil 时没有软分数
+type assignmentInstance struct {
+	difficulties []int
+	values       []interface{}
+	rewards      [][]int
+}
+
+func (in assignmentInstance) newSolution() *testSolution {
+	valueRange := valuerange.NewListValueRange(in.values...)
+	s := &testSolution{}
+	for _, difficulty := range in.difficulties {
+		variable := &strengthVariable{testVariable{valueRange: valueRange}}
+		s.entities = append(s.entities, &difficultyEntity{difficulty: difficulty, variable: variable})
+	}
+	return s
+}
+
+func (in assignmentInstance) newScoreDirector() api.IScoreDirector {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("value used once"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.HARD),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			count := 0
+			entities := s.GetPlanningEntities()
+			for i := range entities {
+				for j := i + 1; j < len(entities); j++ {
+					a := entities[i].GetPlanningVariables()[0].GetValue()
+					b := entities[j].GetPlanningVariables()[0].GetValue()
+					if a != nil && solution.IsSameValue(a, b) {
+						count++
+					}
+				}
+			}
+			return count
+		}),
+	))
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("reward"),
+		constraint.WithWeight(1),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			total := 0
+			for i, entity := range s.GetPlanningEntities() {
+				if value, ok := entity.GetPlanningVariables()[0].GetValue().(int); ok && in.rewards != nil {
+					total += in.rewards[i][value]
+				}
+			}
+			return total
+		}),
+	))
+	return score.NewScoreDirector(score.NewScoreCalculator(cm), cm)
+}
+
+func (in assignmentInstance) construct(cfg config.ConstructionHeuristicConfig) ([]interface{}, error) {
+	s := in.newSolution()
+	scoreDirector := in.newScoreDirector()
+	scoreDirector.SetWorkingSolution(s)
+	if _, err := NewConstructionHeuristic(&cfg, scoreDirector).Construct(context.Background(), s); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0, len(s.entities))
+	for _, entity := range s.entities {
+		values = append(values, entity.GetPlanningVariables()[0].GetValue())
+	}
+	return values, nil
+}
+
+func TestConstructionHeuristicSortedTypes(t *testing.T) {
+	// 实体难度为 1、3、2，递减难度的顺序为实体 1、2、0
+	// 值域顺序为 1、3、0、2，按强度递增为 0、1、2、3，没有软分数时每个实体取排序后第一个未使用的值
+	in := assignmentInstance{
+		difficulties: []int{1, 3, 2},
+		values:       []interface{}{1, 3, 0, 2},
+	}
+	tests := []struct {
+		cfg  config.ConstructionHeuristicConfig
+		want []interface{}
+	}{
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeFirstFit}, []interface{}{1, 3, 0}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeFirstFitDecreasing}, []interface{}{0, 1, 3}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeWeakestFit}, []interface{}{0, 1, 2}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeWeakestFitDecreasing}, []interface{}{2, 0, 1}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeStrongestFit}, []interface{}{3, 2, 1}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeStrongestFitDecreasing}, []interface{}{1, 3, 2}},
+		{config.ConstructionHeuristicConfig{
+			Type:               config.ConstructionHeuristicTypeAllocateEntityFromQueue,
+			EntitySorterManner: config.EntitySorterMannerNone,
+			ValueSorterManner:  config.ValueSorterMannerDecreasingStrength,
+		}, []interface{}{3, 2, 1}},
+		{config.ConstructionHeuristicConfig{Type: config.ConstructionHeuristicTypeAllocateEntityFromQueue}, []interface{}{0, 1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%s/%s", tt.cfg.Type, tt.cfg.EntitySorterManner, tt.cfg.ValueSorterManner), func(t *testing.T) {
+			got, err := in.construct(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstructionHeuristicQueueTypes(t *testing.T) {
+	// 按实体队列时实体 0 先取最佳的值 2；按值队列时值 0 先分配给奖励更高的实体 1
+	// 最便宜插入先执行全局最佳的赋值（实体 1 取值 2，奖励 6）
+	in := assignmentInstance{
+		difficulties: []int{1, 1},
+		values:       []interface{}{0, 1, 2},
+		rewards: [][]int{
+			{1, 0, 5},
+			{2, 0, 6},
+		},
+	}
+	tests := []struct {
+		typ  string
+		want []interface{}
+	}{
+		{config.ConstructionHeuristicTypeFirstFit, []interface{}{2, 0}},
+		{config.ConstructionHeuristicTypeAllocateEntityFromQueue, []interface{}{2, 0}},
+		{config.ConstructionHeuristicTypeAllocateToValueFromQueue, []interface{}{1, 0}},
+		{config.ConstructionHeuristicTypeCheapestInsertion, []interface{}{0, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got, err := in.construct(config.ConstructionHeuristicConfig{
+				Type:               tt.typ,
+				EntitySorterManner: config.EntitySorterMannerNone,
+				ValueSorterManner:  config.ValueSorterMannerNone,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstructionHeuristicUnknownType(t *testing.T) {
+	in := assignmentInstance{difficulties: []int{1, 2}, values: []interface{}{0, 1}}
+	got, err := in.construct(config.ConstructionHeuristicConfig{Type: "BEST_GUESS"})
+	if err == nil || err.Error() != `unknown construction heuristic type "BEST_GUESS"` {
+		t.Fatalf("got error %v, want unknown construction heuristic type", err)
+	}
+	if got != nil {
+		t.Errorf("got values %v", got)
+	}
+}
+
+func TestConstructionHeuristicSliceValues(t *testing.T) {
+	// 切片值不可比较，构造值队列与检查冲突时不应 panic
+	in := assignmentInstance{
+		difficulties: []int{1, 2, 3},
+		values:       []interface{}{[]int{0}, []int{1}, []int{2}},
+	}
+	for _, typ := range []string{
+		config.ConstructionHeuristicTypeFirstFit,
+		config.ConstructionHeuristicTypeAllocateToValueFromQueue,
+		config.ConstructionHeuristicTypeCheapestInsertion,
+	} {
+		t.Run(typ, func(t *testing.T) {
+			got, err := in.construct(config.ConstructionHeuristicConfig{Type: typ})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, value := range got {
+				if value == nil {
+					t.Errorf("entity %d is unassigned", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package heuristic
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/termination"
+)
+
+// 穷举搜索 遍历所有可移动规划变量的赋值组合，用于在小规模问题上求得最优解
+// 分支定界在乐观边界不优于已知最佳解时剪枝，暴力搜索则遍历所有组合
+type ExhaustiveSearch struct {
+	config        *config.ExhaustiveSearchConfig
+	scoreDirector api.IScoreDirector
+	ctx           context.Context
+	// 外部终止检查（如求解器被停止）
+	terminated func() bool
+	// 阶段终止条件
+	termination termination.Termination
+	scope       *termination.Scope
+
+	placements []placement
+	values     [][]interface{}
+	bestPath   []interface{}
+	bestScore  api.IScore
+	nodeCount  int
+	nodeIndex  int
+}
+
+// 搜索树节点，depth 个变量已按 path 赋值
+type searchNode struct {
+	parent          *searchNode
+	depth           int
+	value           interface{}
+	score           api.IScore
+	optimisticBound api.IScore
+	// 创建顺序，用于在优先级相同时保证探索顺序确定
+	index int
+}
+
+func NewExhaustiveSearch(cfg *config.ExhaustiveSearchConfig, scoreDirector api.IScoreDirector) *ExhaustiveSearch {
+	return &ExhaustiveSearch{
+		config:        cfg,
+		scoreDirector: scoreDirector,
+		ctx:           context.Background(),
+		termination:   termination.Build(cfg.Termination),
+		scope:         termination.NewScope(),
+	}
+}
+
+// SetTerminationFunc 设置外部终止检查
+func (e *ExhaustiveSearch) SetTerminationFunc(terminated func() bool) {
+	e.terminated = terminated
+}
+
+// SetParentScope 设置上级运行状态，探索的节点数会累计到上级
+func (e *ExhaustiveSearch) SetParentScope(parent *termination.Scope) {
+	e.scope = termination.NewChildScope(parent)
+}
+
+// GetNodeCount 获取已探索的节点数
+func (e *ExhaustiveSearch) GetNodeCount() int {
+	return e.nodeCount
+}
+
+// Search 搜索最佳赋值并应用到解决方案
+// 提前终止时应用已找到的最佳完整赋值，没有找到时保留原始值
+func (e *ExhaustiveSearch) Search(ctx context.Context, problem api.ISolution) api.ISolution {
+	e.ctx = ctx
+	e.nodeCount = 0
+	e.nodeIndex = 0
+	e.bestPath = nil
+	e.bestScore = nil
+	e.placements = e.getPlacements(problem)
+	e.values = make([][]interface{}, len(e.placements))
+	original := make([]interface{}, len(e.placements))
+	for i, p := range e.placements {
+		e.values[i] = e.getValues(p)
+		original[i] = p.variable.GetValue()
+	}
+
+	rootScore := e.assign(problem, nil)
+	if rootScore == nil {
+		e.restore(problem, original)
+		return problem
+	}
+	e.scope.Start(rootScore)
+	root := &searchNode{score: rootScore, optimisticBound: e.optimisticBound(problem, rootScore)}
+
+	queue := e.newNodeQueue()
+	queue.push(root)
+	for queue.len() > 0 && !e.isTerminated() {
+		node := queue.pop()
+		if e.isPruned(node) {
+			continue
+		}
+		if node.depth == len(e.placements) {
+			e.updateBest(node)
+			continue
+		}
+		if !e.expand(problem, node, queue) {
+			break
+		}
+	}
+
+	if e.bestPath != nil {
+		e.restore(problem, e.bestPath)
+	} else {
+		e.restore(problem, original)
+	}
+	return problem
+}
+
+// expand 生成节点的所有子节点并加入队列，上下文取消时返回 false
+func (e *ExhaustiveSearch) expand(problem api.ISolution, node *searchNode, queue nodeQueue) bool {
+	values := e.values[node.depth]
+	children := make([]*searchNode, 0, len(values))
+	for _, value := range values {
+		e.nodeIndex++
+		child := &searchNode{parent: node, depth: node.depth + 1, value: value, index: e.nodeIndex}
+		child.score = e.assign(problem, child)
+		if child.score == nil {
+			return false
+		}
+		child.optimisticBound = e.optimisticBound(problem, child.score)
+		e.scope.MoveEvaluated()
+		children = append(children, child)
+	}
+	e.nodeCount++
+	e.scope.StepEnded(node.score)
+	for _, child := range children {
+		if !e.isPruned(child) {
+			queue.push(child)
+		}
+	}
+	return true
+}
+
+// assign 按节点路径为变量赋值，其余变量设为未赋值，返回得分，上下文取消时返回 nil
+func (e *ExhaustiveSearch) assign(problem api.ISolution, node *searchNode) api.IScore {
+	path := pathOf(node, len(e.placements))
+	e.restore(problem, path)
+	newScore, err := score.CalculateContext(e.ctx, e.scoreDirector, problem)
+	if err != nil {
+		return nil
+	}
+	return newScore
+}
+
+// restore 按 values 为所有变量赋值
+func (e *ExhaustiveSearch) restore(problem api.ISolution, values []interface{}) {
+	for i, p := range e.placements {
+		if solution.IsSameValue(p.variable.GetValue(), values[i]) {
+			continue
+		}
+		e.scoreDirector.BeforeVariableChanged(p.variable)
+		p.variable.SetValue(values[i])
+		e.scoreDirector.AfterVariableChanged(p.variable)
+	}
+}
+
+func (e *ExhaustiveSearch) updateBest(node *searchNode) {
+	if e.bestScore != nil && node.score.CompareTo(e.bestScore) <= 0 {
+		return
+	}
+	e.bestScore = node.score
+	e.bestPath = pathOf(node, len(e.placements))
+}
+
+// isPruned 分支定界中乐观边界不优于已知最佳解的节点不再探索，没有乐观边界的节点不剪枝
+func (e *ExhaustiveSearch) isPruned(node *searchNode) bool {
+	if e.searchType() != config.ExhaustiveSearchTypeBranchAndBound || e.bestScore == nil || node.optimisticBound == nil {
+		return false
+	}
+	return node.optimisticBound.CompareTo(e.bestScore.WithInitScore(0)) <= 0
+}
+
+// optimisticBound 计算节点的乐观边界，没有可靠的边界时返回 nil
+// 分数指导器未提供边界时，只有配置了 AssumeScoreOnlyWorsens 才以当前分数（忽略初始化分数）作为边界
+// 否则奖励约束会使后续赋值提高分数，以当前分数剪枝会错过最优解
+func (e *ExhaustiveSearch) optimisticBound(problem api.ISolution, current api.IScore) api.IScore {
+	if bounded, ok := e.scoreDirector.(api.IOptimisticBoundScoreDirector); ok {
+		return bounded.CalculateOptimisticBound(problem).WithInitScore(0)
+	}
+	if e.config.AssumeScoreOnlyWorsens {
+		return current.WithInitScore(0)
+	}
+	return nil
+}
+
+// isTerminated 检查穷举搜索阶段是否应当终止
+func (e *ExhaustiveSearch) isTerminated() bool {
+	if e.ctx.Err() != nil {
+		return true
+	}
+	if e.terminated != nil && e.terminated() {
+		return true
+	}
+	return e.termination != nil && e.termination.IsTerminated(e.scope)
+}
+
+func (e *ExhaustiveSearch) searchType() string {
+	if e.config.Type == "" {
+		return config.ExhaustiveSearchTypeBranchAndBound
+	}
+	return e.config.Type
+}
+
+// getPlacements 获取所有未固定实体的规划变量，包括已赋值的变量
+func (e *ExhaustiveSearch) getPlacements(problem api.ISolution) []placement {
+	entities := solution.GetMovablePlanningEntities(problem)
+	if e.config.EntitySorterManner == config.EntitySorterMannerDecreasingDifficulty {
+		sortEntitiesByDecreasingDifficulty(entities)
+	}
+	placements := make([]placement, 0, len(entities))
+	for _, entity := range entities {
+		for _, variable := range entity.GetPlanningVariables() {
+			placements = append(placements, placement{entity: entity, variable: variable})
+		}
+	}
+	return placements
+}
+
+// getValues 获取变量的候选值，可为空的变量最后尝试空值
+func (e *ExhaustiveSearch) getValues(p placement) []interface{} {
+	values := getValues(p, e.config.ValueSorterManner)
+	if solution.IsNullable(p.variable) {
+		values = append(values, nil)
+	}
+	return values
+}
+
+// pathOf 获取从根节点到节点的赋值，未赋值的变量为 nil
+func pathOf(node *searchNode, size int) []interface{} {
+	path := make([]interface{}, size)
+	for n := node; n != nil && n.depth > 0; n = n.parent {
+		path[n.depth-1] = n.value
+	}
+	return path
+}
+
+// 待探索节点的队列，出队顺序由节点探索方式决定
+type nodeQueue interface {
+	push(node *searchNode)
+	pop() *searchNode
+	len() int
+}
+
+func (e *ExhaustiveSearch) newNodeQueue() nodeQueue {
+	if e.searchType() == config.ExhaustiveSearchTypeBruteForce {
+		return &depthFirstQueue{}
+	}
+	switch e.config.NodeExplorationType {
+	case config.NodeExplorationTypeBreadthFirst:
+		return &breadthFirstQueue{}
+	case config.NodeExplorationTypeScoreFirst:
+		return &priorityQueue{less: scoreFirst}
+	case config.NodeExplorationTypeOptimisticBoundFirst:
+		return &priorityQueue{less: optimisticBoundFirst}
+	default:
+		return &depthFirstQueue{}
+	}
+}
+
+// 深度优先，同一父节点的子节点按值的顺序探索
+type depthFirstQueue struct {
+	nodes []*searchNode
+	// 同一父节点的子节点先缓存，出队前按相反顺序压栈
+	pending []*searchNode
+}
+
+func (q *depthFirstQueue) push(node *searchNode) {
+	q.pending = append(q.pending, node)
+}
+
+func (q *depthFirstQueue) pop() *searchNode {
+	q.flush()
+	node := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return node
+}
+
+func (q *depthFirstQueue) len() int {
+	return len(q.nodes) + len(q.pending)
+}
+
+func (q *depthFirstQueue) flush() {
+	for i := len(q.pending) - 1; i >= 0; i-- {
+		q.nodes = append(q.nodes, q.pending[i])
+	}
+	q.pending = q.pending[:0]
+}
+
+// 广度优先
+type breadthFirstQueue struct {
+	nodes []*searchNode
+}
+
+func (q *breadthFirstQueue) push(node *searchNode) {
+	q.nodes = append(q.nodes, node)
+}
+
+func (q *breadthFirstQueue) pop() *searchNode {
+	node := q.nodes[0]
+	q.nodes = q.nodes[1:]
+	return node
+}
+
+func (q *breadthFirstQueue) len() int {
+	return len(q.nodes)
+}
+
+// 按优先级出队，less(a, b) 为 true 表示 a 先于 b 探索
+type priorityQueue struct {
+	nodes []*searchNode
+	less  func(a, b *searchNode) bool
+}
+
+func (q *priorityQueue) push(node *searchNode) { heap.Push((*nodeHeap)(q), node) }
+func (q *priorityQueue) pop() *searchNode      { return heap.Pop((*nodeHeap)(q)).(*searchNode) }
+func (q *priorityQueue) len() int              { return len(q.nodes) }
+
+type nodeHeap priorityQueue
+
+func (h *nodeHeap) Len() int           { return len(h.nodes) }
+func (h *nodeHeap) Less(i, j int) bool { return h.less(h.nodes[i], h.nodes[j]) }
+func (h *nodeHeap) Swap(i, j int)      { h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i] }
+func (h *nodeHeap) Push(x any)         { h.nodes = append(h.nodes, x.(*searchNode)) }
+func (h *nodeHeap) Pop() any {
+	node := h.nodes[len(h.nodes)-1]
+	h.nodes = h.nodes[:len(h.nodes)-1]
+	return node
+}
+
+// scoreFirst 分数高的节点优先，其次是更深的节点
+func scoreFirst(a, b *searchNode) bool {
+	if c := a.score.WithInitScore(0).CompareTo(b.score.WithInitScore(0)); c != 0 {
+		return c > 0
+	}
+	return deeperFirst(a, b)
+}
+
+// optimisticBoundFirst 乐观边界高的节点优先，其次是分数高的节点，没有乐观边界时按分数
+func optimisticBoundFirst(a, b *searchNode) bool {
+	if a.optimisticBound == nil || b.optimisticBound == nil {
+		return scoreFirst(a, b)
+	}
+	if c := a.optimisticBound.CompareTo(b.optimisticBound); c != 0 {
+		return c > 0
+	}
+	return scoreFirst(a, b)
+}
+
+func deeperFirst(a, b *searchNode) bool {
+	if a.depth != b.depth {
+		return a.depth > b.depth
+	}
+	return a.index < b.index
+}
@@ -0,0 +1,189 @@
+package heuristic
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+type testVariable struct {
+	value      interface{}
+	valueRange api.IValueRange
+}
+
+func (v *testVariable) GetValue() interface{}          { return v.value }
+func (v *testVariable) SetValue(value interface{})     { v.value = value }
+func (v *testVariable) GetValueRange() api.IValueRange { return v.valueRange }
+
+type testEntity struct {
+	variable *testVariable
+}
+
+func (e *testEntity) PlanningFilter() {}
+func (e *testEntity) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{e.variable}
+}
+
+type testSolution struct {
+	score    api.IScore
+	entities []api.IPlanningEntity
+}
+
+func (s *testSolution) GetScore() api.IScore                               { return s.score }
+func (s *testSolution) SetScore(score api.IScore)                          { s.score = score }
+func (s *testSolution) GetPlanningEntities() []api.IPlanningEntity         { return s.entities }
+func (s *testSolution) SetPlanningEntities(entities []api.IPlanningEntity) { s.entities = entities }
+func (s *testSolution) GetProblemFacts() []interface{}                     { return nil }
+func (s *testSolution) SetProblemFacts(facts []interface{})                {}
+
+// rewardInstance 实体数为 len(rewards)，值为 0..valueCount-1
+// 相邻实体取相同值时惩罚 1 个硬分数，实体 i 取值 v 时的软分数为 rewards[i][v]，可正可负
+type rewardInstance struct {
+	rewards    [][]int
+	valueCount int
+}
+
+func newRandomInstance(seed int64, entityCount, valueCount int, onlyPenalties bool) rewardInstance {
+	random := rand.New(rand.NewSource(seed))
+	rewards := make([][]int, entityCount)
+	for i := range rewards {
+		rewards[i] = make([]int, valueCount)
+		for v := range rewards[i] {
+			rewards[i][v] = random.Intn(21) - 10
+			if onlyPenalties && rewards[i][v] > 0 {
+				rewards[i][v] = -rewards[i][v]
+			}
+		}
+	}
+	return rewardInstance{rewards: rewards, valueCount: valueCount}
+}
+
+func (in rewardInstance) newSolution() *testSolution {
+	values := make([]interface{}, in.valueCount)
+	for v := range values {
+		values[v] = v
+	}
+	valueRange := valuerange.NewListValueRange(values...)
+	s := &testSolution{}
+	for range in.rewards {
+		s.entities = append(s.entities, &testEntity{variable: &testVariable{valueRange: valueRange}})
+	}
+	return s
+}
+
+func (in rewardInstance) newScoreDirector() api.IScoreDirector {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("adjacent equal"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.HARD),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			count := 0
+			entities := s.GetPlanningEntities()
+			for i := 1; i < len(entities); i++ {
+				a := entities[i-1].GetPlanningVariables()[0].GetValue()
+				b := entities[i].GetPlanningVariables()[0].GetValue()
+				if a != nil && a == b {
+					count++
+				}
+			}
+			return count
+		}),
+	))
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("reward"),
+		constraint.WithWeight(1),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			total := 0
+			for i, entity := range s.GetPlanningEntities() {
+				if value := entity.GetPlanningVariables()[0].GetValue(); value != nil {
+					total += in.rewards[i][value.(int)]
+				}
+			}
+			return total
+		}),
+	))
+	return score.NewScoreDirector(score.NewScoreCalculator(cm), cm)
+}
+
+func (in rewardInstance) search(t *testing.T, cfg config.ExhaustiveSearchConfig) api.IScore {
+	t.Helper()
+	solution := in.newSolution()
+	scoreDirector := in.newScoreDirector()
+	scoreDirector.SetWorkingSolution(solution)
+	NewExhaustiveSearch(&cfg, scoreDirector).Search(context.Background(), solution)
+	for i, entity := range solution.GetPlanningEntities() {
+		if entity.GetPlanningVariables()[0].GetValue() == nil {
+			t.Fatalf("entity %d is unassigned after exhaustive search", i)
+		}
+	}
+	return scoreDirector.Calculate(solution)
+}
+
+func TestExhaustiveSearchFindsKnownOptimum(t *testing.T) {
+	// 最优解为 [1, 0, 1]（软分数 -3+0+5=2），深度优先先找到 [0, 1, 0]（软分数 -1）
+	// 以部分赋值的分数作为边界时 [1] 的分数 -3 不优于 -1 而被剪枝，错过奖励 5
+	in := rewardInstance{
+		rewards: [][]int{
+			{0, -3},
+			{0, 0},
+			{-1, 5},
+		},
+		valueCount: 2,
+	}
+	for _, cfg := range []config.ExhaustiveSearchConfig{
+		{Type: config.ExhaustiveSearchTypeBruteForce},
+		{Type: config.ExhaustiveSearchTypeBranchAndBound},
+	} {
+		got := in.search(t, cfg)
+		if got.ToShortString() != "HardSoftScore[initScore=0, hardScore=0, softScore=2]" {
+			t.Errorf("%s: got %s, want hard 0 soft 2", cfg.Type, got.ToShortString())
+		}
+	}
+}
+
+func TestBranchAndBoundMatchesBruteForce(t *testing.T) {
+	explorationTypes := []string{
+		config.NodeExplorationTypeDepthFirst,
+		config.NodeExplorationTypeBreadthFirst,
+		config.NodeExplorationTypeScoreFirst,
+		config.NodeExplorationTypeOptimisticBoundFirst,
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		in := newRandomInstance(seed, 4, 3, false)
+		want := in.search(t, config.ExhaustiveSearchConfig{Type: config.ExhaustiveSearchTypeBruteForce})
+		for _, explorationType := range explorationTypes {
+			t.Run(fmt.Sprintf("seed%d/%s", seed, explorationType), func(t *testing.T) {
+				got := in.search(t, config.ExhaustiveSearchConfig{
+					Type:                config.ExhaustiveSearchTypeBranchAndBound,
+					NodeExplorationType: explorationType,
+				})
+				if got.CompareTo(want) != 0 {
+					t.Errorf("branch and bound found %s, brute force found %s", got.ToShortString(), want.ToShortString())
+				}
+			})
+		}
+	}
+}
+
+func TestBranchAndBoundAssumingScoreOnlyWorsensMatchesBruteForce(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		in := newRandomInstance(seed, 4, 3, true)
+		want := in.search(t, config.ExhaustiveSearchConfig{Type: config.ExhaustiveSearchTypeBruteForce})
+		got := in.search(t, config.ExhaustiveSearchConfig{
+			Type:                   config.ExhaustiveSearchTypeBranchAndBound,
+			AssumeScoreOnlyWorsens: true,
+		})
+		if got.CompareTo(want) != 0 {
+			t.Errorf("seed %d: branch and bound found %s, brute force found %s", seed, got.ToShortString(), want.ToShortString())
+		}
+	}
+}
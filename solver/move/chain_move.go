@@ -1,6 +1,11 @@
 package move
 
-import "github.com/kruily/go-timefold-solver/solver/api"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
 
 type ChainMove struct {
 	moveList      []api.IMove
@@ -29,3 +34,28 @@ func (m *ChainMove) Undo(workingSolution api.ISolution) {
 func (m *ChainMove) Accept(scoreDirector api.IScoreDirector) bool {
 	return true
 }
+
+func (m *ChainMove) String() string {
+	moves := make([]string, 0, len(m.moveList))
+	for _, move := range m.moveList {
+		moves = append(moves, fmt.Sprint(move))
+	}
+	return fmt.Sprintf("ChainMove[%s]", strings.Join(moves, ", "))
+}
+
+// Rebase 创建作用于目标解决方案的相同移动，子移动不可转移时返回 nil
+func (m *ChainMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	moves := make([]api.IMove, 0, len(m.moveList))
+	for _, move := range m.moveList {
+		rebasable, ok := move.(api.IRebasableMove)
+		if !ok {
+			return nil
+		}
+		rebased := rebasable.Rebase(lookup, scoreDirector)
+		if rebased == nil {
+			return nil
+		}
+		moves = append(moves, rebased)
+	}
+	return NewChainMove(moves, scoreDirector)
+}
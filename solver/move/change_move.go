@@ -1,11 +1,16 @@
 package move
 
-import "github.com/kruily/go-timefold-solver/solver/api"
+import (
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
 
 type ChangeMove struct {
 	entity        api.IPlanningEntity
 	variable      api.IPlanningVariable
 	targetValue   interface{}
+	oldValue      interface{}
 	scoreDirector api.IScoreDirector
 }
 
@@ -19,17 +24,27 @@ func NewChangeMove(entity api.IPlanningEntity, variable api.IPlanningVariable, t
 }
 
 func (m *ChangeMove) Execute(workingSolution api.ISolution) {
-	oldValue := m.variable.GetValue()
+	m.oldValue = m.variable.GetValue() // 保存旧值，用于撤销
 	m.scoreDirector.BeforeVariableChanged(m.variable)
 	m.variable.SetValue(m.targetValue)
 	m.scoreDirector.AfterVariableChanged(m.variable)
-	m.targetValue = oldValue // 保存旧值，用于撤销
 }
 
 func (m *ChangeMove) Undo(workingSolution api.ISolution) {
-	m.variable.SetValue(m.targetValue)
+	m.scoreDirector.BeforeVariableChanged(m.variable)
+	m.variable.SetValue(m.oldValue)
+	m.scoreDirector.AfterVariableChanged(m.variable)
 }
 
 func (m *ChangeMove) Accept(scoreDirector api.IScoreDirector) bool {
 	return true
 }
+
+func (m *ChangeMove) String() string {
+	return fmt.Sprintf("ChangeMove(entity=%v, value=%v)", m.entity, m.targetValue)
+}
+
+// Rebase 创建作用于目标解决方案的相同移动
+func (m *ChangeMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	return NewChangeMove(lookup.LookUpEntity(m.entity), lookup.LookUpVariable(m.variable), m.targetValue, scoreDirector)
+}
@@ -1,15 +1,25 @@
 package move
 
 import (
+	"context"
+	"math"
 	"math/rand"
-	"time"
+	"sort"
 
 	"github.com/kruily/go-timefold-solver/solver/api"
 	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/nearby"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
 )
 
+// 并行评估首个可行移动时每批移动数与线程数的倍数
+const parallelBatchFactor = 4
+
 type MoveSelector interface {
-	SelectMove(solution api.ISolution) api.IMove
+	// SelectMove 选择下一个移动，上下文取消时返回 nil
+	SelectMove(ctx context.Context, solution api.ISolution) api.IMove
 	Reset()
 }
 
@@ -17,118 +27,157 @@ type DefaultMoveSelector struct {
 	config        *config.SolverConfig
 	scoreDirector api.IScoreDirector
 	random        *rand.Rand
+	// 柱移动使用的柱选择器
+	pillarSelector *PillarSelector
+	// 就近选择的距离度量与概率分布，距离矩阵在每个阶段开始时重建
+	nearbyMeter   nearby.NearbyDistanceMeter
+	nearbyRandom  nearby.NearbyRandom
+	entityMatrix  *nearby.NearbyDistanceMatrix
+	valueMatrices map[int]*nearby.NearbyDistanceMatrix
+	// 启用并行时的移动评估器，为 nil 时在当前解决方案上依次评估
+	parallelEvaluator *ParallelMoveEvaluator
+	// 非空时在每次评估移动后断言分数，用于 FULL_ASSERT 环境模式
+	asserter  *score.ScoreAsserter
+	assertErr error
+	// 选择移动时遇到的错误（如柱选择遇到不可比较的值），求解器据此结束求解
+	selectErr error
 }
 
-func NewDefaultMoveSelector(config *config.SolverConfig, scoreDirector api.IScoreDirector) *DefaultMoveSelector {
-	return &DefaultMoveSelector{
+// NewDefaultMoveSelector 创建移动选择器，random 为求解器范围的随机源
+func NewDefaultMoveSelector(config *config.SolverConfig, scoreDirector api.IScoreDirector, random *rand.Rand) *DefaultMoveSelector {
+	selector := &DefaultMoveSelector{
 		config:        config,
 		scoreDirector: scoreDirector,
-		random:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		random:        random,
+	}
+	selector.pillarSelector = NewPillarSelector(&config.PillarSelectorConfig, random)
+	if config.Parallel && config.ParallelThreadCount > 1 {
+		selector.parallelEvaluator = NewParallelMoveEvaluator(config.ParallelThreadCount, scoreDirector)
 	}
+	return selector
 }
 
-func (s *DefaultMoveSelector) SelectMove(solution api.ISolution) api.IMove {
+func (s *DefaultMoveSelector) SelectMove(ctx context.Context, solution api.ISolution) api.IMove {
 	switch s.config.MoveSelector {
 	case config.MOVE_SELECTOR_FIRST_FIT:
-		return s.selectFirstFitMove(solution)
+		return s.selectFirstFitMove(ctx, solution)
 	case config.MOVE_SELECTOR_BEST_FIT:
-		return s.selectBestFitMove(solution)
+		return s.selectBestFitMove(ctx, solution)
 	case config.MOVE_SELECTOR_CHANGE:
-		return s.selectChangeMove(solution)
+		return s.selectChangeMove(ctx, solution)
 	case config.MOVE_SELECTOR_CHAINED:
-		return s.selectChainedMove(solution)
+		return s.selectChainedMove(ctx, solution)
+	case config.MOVE_SELECTOR_RANDOM:
+		return s.selectRandomMove(ctx, solution)
+	case config.MOVE_SELECTOR_RUIN_RECREATE:
+		return s.selectRuinRecreateMove(ctx, solution)
+	case config.MOVE_SELECTOR_PILLAR_CHANGE:
+		return s.selectPillarChangeMove(ctx, solution)
+	case config.MOVE_SELECTOR_PILLAR_SWAP:
+		return s.selectPillarSwapMove(ctx, solution)
+	case config.MOVE_SELECTOR_RANDOM_CHANGE:
+		return s.selectRandomChangeMove(ctx, solution)
 	default:
-		return s.selectFirstFitMove(solution)
+		return s.selectFirstFitMove(ctx, solution)
 	}
 }
 
-func (s *DefaultMoveSelector) selectFirstFitMove(solution api.ISolution) api.IMove {
+func (s *DefaultMoveSelector) selectFirstFitMove(ctx context.Context, solution api.ISolution) api.IMove {
 	entities := s.getPlanningEntities(solution)
 	if len(entities) < 2 {
 		return nil
 	}
+	moves := make([]api.IMove, 0)
 	for i := 0; i < len(entities); i++ {
 		for j := i + 1; j < len(entities); j++ {
-
+			if ctx.Err() != nil {
+				return nil
+			}
 			vars1 := entities[i].GetPlanningVariables()
 			vars2 := entities[j].GetPlanningVariables()
+			if len(vars2) == 0 {
+				continue
+			}
 			for _, v1 := range vars1 {
-				move := NewSwapMove(entities[i], entities[j], v1, vars2[0], s.scoreDirector)
-				if s.isFeasibleMove(solution, move) {
-					return move
+				if !s.isSwappable(entities[i], v1, entities[j], vars2[0]) {
+					continue
 				}
+				moves = append(moves, NewSwapMove(entities[i], entities[j], v1, vars2[0], s.scoreDirector))
 			}
 		}
 	}
-	return nil
+	return s.pickFirstFeasibleMove(ctx, solution, moves)
 }
 
-func (s *DefaultMoveSelector) selectBestFitMove(solution api.ISolution) api.IMove {
+func (s *DefaultMoveSelector) selectBestFitMove(ctx context.Context, solution api.ISolution) api.IMove {
 	entities := s.getPlanningEntities(solution)
 	if len(entities) < 2 {
 		return nil
 	}
-	var bestMove api.IMove
-	var bestScore api.IScore
+	moves := make([]api.IMove, 0)
 	for i := 0; i < len(entities); i++ {
 		for j := i + 1; j < len(entities); j++ {
+			if ctx.Err() != nil {
+				return nil
+			}
 			vars1 := entities[i].GetPlanningVariables()
 			vars2 := entities[j].GetPlanningVariables()
 			for _, v1 := range vars1 {
 				for _, v2 := range vars2 {
-					move := NewSwapMove(entities[i], entities[j], v1, v2, s.scoreDirector)
-					if !s.isFeasibleMove(solution, move) {
+					if !s.isSwappable(entities[i], v1, entities[j], v2) {
 						continue
 					}
-					score := s.evaluateMove(move, solution)
-					if bestMove == nil || score.CompareTo(bestScore) > 0 {
-						bestMove = move
-						bestScore = score
-					}
+					moves = append(moves, NewSwapMove(entities[i], entities[j], v1, v2, s.scoreDirector))
 				}
 			}
 		}
 	}
-	return bestMove
+	return s.pickBestMove(ctx, solution, moves)
 }
 
-func (s *DefaultMoveSelector) selectRandomMove(solution api.ISolution) api.IMove {
+func (s *DefaultMoveSelector) selectRandomMove(ctx context.Context, solution api.ISolution) api.IMove {
 	entities := s.getPlanningEntities(solution)
 	if len(entities) < 2 {
 		return nil
 	}
 	maxAttempts := 10
 	for attempts := 0; attempts < maxAttempts; attempts++ {
-		i := s.random.Intn(len(entities))
-		j := s.random.Intn(len(entities))
-		if i == j {
+		if ctx.Err() != nil {
+			return nil
+		}
+		left := entities[s.random.Intn(len(entities))]
+		right, ok := s.randomSwapEntity(solution, entities, left)
+		if !ok {
 			continue
 		}
-		vars1 := entities[i].GetPlanningVariables()
-		vars2 := entities[j].GetPlanningVariables()
+		vars1 := left.GetPlanningVariables()
+		vars2 := right.GetPlanningVariables()
 		if len(vars1) == 0 || len(vars2) == 0 {
 			continue
 		}
 		v1 := vars1[s.random.Intn(len(vars1))]
 		v2 := vars2[s.random.Intn(len(vars2))]
-		move := NewSwapMove(entities[i], entities[j], v1, v2, s.scoreDirector)
-		if s.isFeasibleMove(solution, move) {
+		if !s.isSwappable(left, v1, right, v2) {
+			continue
+		}
+		move := NewSwapMove(left, right, v1, v2, s.scoreDirector)
+		if s.isFeasibleMove(ctx, solution, move) {
 			return move
 		}
 	}
 	return nil
 }
 
-func (s *DefaultMoveSelector) selectChainedMove(solution api.ISolution) api.IMove {
+func (s *DefaultMoveSelector) selectChainedMove(ctx context.Context, solution api.ISolution) api.IMove {
 	moves := make([]api.IMove, 0)
 
-	move1 := s.selectRandomMove(solution)
+	move1 := s.selectRandomMove(ctx, solution)
 	if move1 == nil {
 		return nil
 	}
 	moves = append(moves, move1)
 	if s.random.Float64() < 0.5 {
-		move2 := s.selectRandomMove(solution)
+		move2 := s.selectRandomMove(ctx, solution)
 		if move2 != nil {
 			moves = append(moves, move2)
 		}
@@ -136,55 +185,331 @@ func (s *DefaultMoveSelector) selectChainedMove(solution api.ISolution) api.IMov
 	return NewChainMove(moves, s.scoreDirector)
 }
 
-func (s *DefaultMoveSelector) selectChangeMove(solution api.ISolution) api.IMove {
+// selectPillarChangeMove 随机选择子柱与目标值，返回第一个可行的柱变更移动
+func (s *DefaultMoveSelector) selectPillarChangeMove(ctx context.Context, workingSolution api.ISolution) api.IMove {
+	maxAttempts := 10
+	for attempts := 0; attempts < maxAttempts; attempts++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		variableIndex, ok := s.randomVariableIndex(workingSolution)
+		if !ok {
+			return nil
+		}
+		pillars, err := s.pillarSelector.GetPillars(workingSolution, variableIndex)
+		if err != nil {
+			s.selectErr = err
+			return nil
+		}
+		if len(pillars) == 0 {
+			return nil
+		}
+		pillar := s.pillarSelector.RandomSubPillar(pillars[s.random.Intn(len(pillars))])
+		variable := pillar[0].GetPlanningVariables()[variableIndex]
+		value, ok := valuerange.RandomValue(valuerange.Of(pillar[0], variable), s.random)
+		if !ok || solution.IsSameValue(value, variable.GetValue()) || !acceptsValue(pillar, variableIndex, value) {
+			continue
+		}
+		move := NewPillarChangeMove(pillar, variableIndex, value, s.scoreDirector)
+		if s.isFeasibleMove(ctx, workingSolution, move) {
+			return move
+		}
+	}
+	return nil
+}
+
+// selectPillarSwapMove 随机选择两个不同值的子柱，返回第一个可行的柱交换移动
+func (s *DefaultMoveSelector) selectPillarSwapMove(ctx context.Context, solution api.ISolution) api.IMove {
+	maxAttempts := 10
+	for attempts := 0; attempts < maxAttempts; attempts++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		variableIndex, ok := s.randomVariableIndex(solution)
+		if !ok {
+			return nil
+		}
+		pillars, err := s.pillarSelector.GetPillars(solution, variableIndex)
+		if err != nil {
+			s.selectErr = err
+			return nil
+		}
+		if len(pillars) < 2 {
+			return nil
+		}
+		i := s.random.Intn(len(pillars))
+		j := s.random.Intn(len(pillars))
+		if i == j {
+			continue
+		}
+		left := s.pillarSelector.RandomSubPillar(pillars[i])
+		right := s.pillarSelector.RandomSubPillar(pillars[j])
+		leftValue := left[0].GetPlanningVariables()[variableIndex].GetValue()
+		rightValue := right[0].GetPlanningVariables()[variableIndex].GetValue()
+		if !acceptsValue(left, variableIndex, rightValue) || !acceptsValue(right, variableIndex, leftValue) {
+			continue
+		}
+		move := NewPillarSwapMove(left, right, variableIndex, s.scoreDirector)
+		if s.isFeasibleMove(ctx, solution, move) {
+			return move
+		}
+	}
+	return nil
+}
+
+// randomVariableIndex 随机选择规划变量的下标，以第一个可移动实体的变量数为准
+func (s *DefaultMoveSelector) randomVariableIndex(solution api.ISolution) (int, bool) {
 	entities := s.getPlanningEntities(solution)
+	if len(entities) == 0 {
+		return 0, false
+	}
+	count := len(entities[0].GetPlanningVariables())
+	if count == 0 {
+		return 0, false
+	}
+	return s.random.Intn(count), true
+}
+
+// acceptsValue 柱中每个实体的值范围是否都包含该值
+func acceptsValue(pillar []api.IPlanningEntity, variableIndex int, value interface{}) bool {
+	for _, entity := range pillar {
+		if !valuerange.Contains(valuerange.Of(entity, entity.GetPlanningVariables()[variableIndex]), value) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetPillarSequenceComparator 设置 SEQUENCE 子柱中实体的顺序
+func (s *DefaultMoveSelector) SetPillarSequenceComparator(comparator api.IComparator[api.IPlanningEntity]) {
+	s.pillarSelector.SetSequenceComparator(comparator)
+}
+
+// selectRuinRecreateMove 随机选取一组可移动的实体进行破坏并重建
+func (s *DefaultMoveSelector) selectRuinRecreateMove(ctx context.Context, solution api.ISolution) api.IMove {
+	entities := s.getPlanningEntities(solution)
+	if len(entities) == 0 || ctx.Err() != nil {
+		return nil
+	}
+	minCount, maxCount := s.ruinedCountRange(len(entities))
+	count := minCount + s.random.Intn(maxCount-minCount+1)
+	// 随机选出 count 个不同的实体，按原有顺序排列以便重建顺序确定
+	indexes := s.random.Perm(len(entities))[:count]
+	sort.Ints(indexes)
+	ruined := make([]api.IPlanningEntity, 0, count)
+	for _, index := range indexes {
+		ruined = append(ruined, entities[index])
+	}
+	move := NewRuinRecreateMove(ruined, s.recreateConfig(), s.scoreDirector)
+	move.ctx = ctx
+	return move
+}
+
+// ruinedCountRange 根据配置计算破坏的实体数范围，限制在 [1, entityCount] 内
+func (s *DefaultMoveSelector) ruinedCountRange(entityCount int) (int, int) {
+	rr := &s.config.RuinRecreateConfig
+	minCount, maxCount := rr.MinimumRuinedCount, rr.MaximumRuinedCount
+	if rr.IsPercentageBased() {
+		minCount = int(math.Floor(rr.MinimumRuinedPercentage * float64(entityCount)))
+		maxCount = int(math.Ceil(rr.MaximumRuinedPercentage * float64(entityCount)))
+	}
+	maxCount = min(max(maxCount, 1), entityCount)
+	minCount = min(max(minCount, 1), maxCount)
+	return minCount, maxCount
+}
+
+// recreateConfig 重建使用的构造启发式配置，未配置类型时使用 FIRST_FIT
+func (s *DefaultMoveSelector) recreateConfig() *config.ConstructionHeuristicConfig {
+	chConfig := s.config.RuinRecreateConfig.ConstructionHeuristicConfig
+	if chConfig.Type == "" {
+		chConfig.Type = config.ConstructionHeuristicTypeFirstFit
+	}
+	return &chConfig
+}
 
+func (s *DefaultMoveSelector) selectChangeMove(ctx context.Context, workingSolution api.ISolution) api.IMove {
+	entities := s.getPlanningEntities(workingSolution)
+
+	moves := make([]api.IMove, 0)
 	for _, entity := range entities {
+		if ctx.Err() != nil {
+			return nil
+		}
 		variables := entity.GetPlanningVariables()
 		for _, variable := range variables {
-			valueRange := variable.GetValueRange()
-			iterator := valueRange.CreateIterator()
-			for iterator.HasNext() {
-				value := iterator.Next()
-				if value == variable.GetValue() {
+			values := valuerange.ToSlice(valuerange.Of(entity, variable))
+			// 可为空的变量也可以变为未赋值
+			if solution.IsNullable(variable) {
+				values = append(values, nil)
+			}
+			for _, value := range values {
+				if solution.IsSameValue(value, variable.GetValue()) {
 					continue
 				}
-				mov := NewChangeMove(entity, variable, value, s.scoreDirector)
-				if s.isFeasibleMove(solution, mov) {
-					return mov
-				}
+				moves = append(moves, NewChangeMove(entity, variable, value, s.scoreDirector))
+			}
+		}
+	}
+	return s.pickFirstFeasibleMove(ctx, workingSolution, moves)
+}
+
+// pickFirstFeasibleMove 按顺序返回第一个可行的移动
+// 并行评估时按批评估，仍然返回顺序上第一个可行的移动
+func (s *DefaultMoveSelector) pickFirstFeasibleMove(ctx context.Context, solution api.ISolution, moves []api.IMove) api.IMove {
+	batchSize := 1
+	if s.isParallel(solution) {
+		batchSize = s.config.ParallelThreadCount * parallelBatchFactor
+	}
+	for start := 0; start < len(moves); start += batchSize {
+		end := start + batchSize
+		if end > len(moves) {
+			end = len(moves)
+		}
+		scores := s.evaluateMoves(ctx, solution, moves[start:end])
+		if scores == nil {
+			return nil
+		}
+		for i, moveScore := range scores {
+			if moveScore.IsFeasible() {
+				return moves[start+i]
 			}
 		}
 	}
 	return nil
 }
 
-func (s *DefaultMoveSelector) isFeasibleMove(solution api.ISolution, move api.IMove) bool {
-	move.Execute(solution)
-	score := s.scoreDirector.Calculate(solution)
-	feasible := score.IsFeasible()
-	move.Undo(solution)
-	return feasible
+// pickBestMove 返回得分最高的可行移动，得分相同时取顺序靠前的移动
+func (s *DefaultMoveSelector) pickBestMove(ctx context.Context, solution api.ISolution, moves []api.IMove) api.IMove {
+	scores := s.evaluateMoves(ctx, solution, moves)
+	if scores == nil {
+		return nil
+	}
+	var bestMove api.IMove
+	var bestScore api.IScore
+	for i, moveScore := range scores {
+		if !moveScore.IsFeasible() {
+			continue
+		}
+		if bestMove == nil || moveScore.CompareTo(bestScore) > 0 {
+			bestMove = moves[i]
+			bestScore = moveScore
+		}
+	}
+	return bestMove
 }
 
-func (s *DefaultMoveSelector) evaluateMove(move api.IMove, solution api.ISolution) api.IScore {
+// evaluateMoves 评估一组移动，上下文取消或检测到分数损坏时返回 nil
+func (s *DefaultMoveSelector) evaluateMoves(ctx context.Context, solution api.ISolution, moves []api.IMove) []api.IScore {
+	if s.isParallel(solution) {
+		scores, err := s.parallelEvaluator.EvaluateMoves(ctx, solution, moves)
+		if err != nil {
+			return nil
+		}
+		return scores
+	}
+	scores := make([]api.IScore, 0, len(moves))
+	for _, move := range moves {
+		moveScore := s.evaluateMove(ctx, move, solution)
+		if moveScore == nil {
+			return nil
+		}
+		scores = append(scores, moveScore)
+	}
+	return scores
+}
+
+// isParallel 是否在多个工作协程上评估移动，断言模式下始终在当前解决方案上评估
+func (s *DefaultMoveSelector) isParallel(solution api.ISolution) bool {
+	return s.parallelEvaluator != nil && s.asserter == nil && s.parallelEvaluator.Supports(solution)
+}
+
+// isSwappable 交换后的值是否都在各自实体的值范围内
+func (s *DefaultMoveSelector) isSwappable(e1 api.IPlanningEntity, v1 api.IPlanningVariable, e2 api.IPlanningEntity, v2 api.IPlanningVariable) bool {
+	value1 := v1.GetValue()
+	value2 := v2.GetValue()
+	if solution.IsSameValue(value1, value2) {
+		return false
+	}
+	if value2 != nil && !valuerange.Contains(valuerange.Of(e1, v1), value2) {
+		return false
+	}
+	if value1 != nil && !valuerange.Contains(valuerange.Of(e2, v2), value1) {
+		return false
+	}
+	return true
+}
+
+func (s *DefaultMoveSelector) isFeasibleMove(ctx context.Context, solution api.ISolution, move api.IMove) bool {
+	moveScore := s.evaluateMove(ctx, move, solution)
+	return moveScore != nil && moveScore.IsFeasible()
+}
+
+// evaluateMove 评估移动后的得分，上下文取消或检测到分数损坏时返回 nil
+func (s *DefaultMoveSelector) evaluateMove(ctx context.Context, move api.IMove, solution api.ISolution) api.IScore {
+	if s.assertErr != nil {
+		return nil
+	}
+	var before *score.ScoreSnapshot
+	if s.asserter != nil {
+		before = s.asserter.Snapshot(solution)
+	}
 	move.Execute(solution)
-	score := s.scoreDirector.Calculate(solution)
+	moveScore, err := score.CalculateContext(ctx, s.scoreDirector, solution)
+	if err == nil && s.asserter != nil {
+		s.assertErr = s.asserter.AssertWorkingScore(before, moveScore, solution, move, "after move")
+	}
 	move.Undo(solution)
-	return score
+	if err != nil {
+		return nil
+	}
+	if s.asserter != nil && s.assertErr == nil {
+		s.assertErr = s.asserter.AssertUndo(before, solution, move)
+	}
+	if s.assertErr != nil {
+		return nil
+	}
+	return moveScore
 }
 
-func (s *DefaultMoveSelector) getPlanningEntities(solution api.ISolution) []api.IPlanningEntity {
-	var entities []api.IPlanningEntity
-	facts := solution.GetProblemFacts()
-	for _, fact := range facts {
-		if entity, ok := fact.(api.IPlanningEntity); ok {
-			entities = append(entities, entity)
-		}
+// SetScoreAsserter 设置分数断言器，评估每个移动时从头计算分数并检查撤销
+func (s *DefaultMoveSelector) SetScoreAsserter(asserter *score.ScoreAsserter) {
+	s.asserter = asserter
+}
+
+// PhaseStarted 在局部搜索阶段开始时调用，重建就近选择的距离矩阵与并行评估的工作协程
+func (s *DefaultMoveSelector) PhaseStarted(workingSolution api.ISolution) {
+	s.buildNearbyMatrices(workingSolution)
+	if s.parallelEvaluator != nil {
+		s.parallelEvaluator.PhaseStarted()
+	}
+}
+
+// StepTaken 在工作解决方案接受移动之后调用，并行评估时在各工作协程上重放该移动
+// 阶段内对工作解决方案的其他修改需要重新调用 PhaseStarted
+func (s *DefaultMoveSelector) StepTaken(move api.IMove) {
+	if s.parallelEvaluator != nil {
+		s.parallelEvaluator.StepTaken(move)
 	}
-	return entities
 }
 
+// AssertionError 获取评估移动时检测到的分数损坏
+func (s *DefaultMoveSelector) AssertionError() error {
+	return s.assertErr
+}
+
+// SelectionError 获取选择移动时遇到的错误
+func (s *DefaultMoveSelector) SelectionError() error {
+	return s.selectErr
+}
+
+// getPlanningEntities 获取可移动的规划实体，固定的实体不参与移动
+func (s *DefaultMoveSelector) getPlanningEntities(workingSolution api.ISolution) []api.IPlanningEntity {
+	return solution.GetMovablePlanningEntities(workingSolution)
+}
+
+// Reset 将随机源重置为配置的随机种子
 func (s *DefaultMoveSelector) Reset() {
-	s.random = rand.New(rand.NewSource(s.config.RandomSeed))
+	s.random.Seed(s.config.RandomSeed)
+	s.assertErr = nil
+	s.selectErr = nil
 }
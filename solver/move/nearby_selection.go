@@ -0,0 +1,122 @@
+package move
+
+import (
+	"context"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/nearby"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+// SetNearbyDistanceMeter 设置就近选择使用的距离度量
+// 度量需要同时支持实体到实体（RANDOM 的交换目标）和实体到值（RANDOM_CHANGE 的目标值）的距离
+func (s *DefaultMoveSelector) SetNearbyDistanceMeter(meter nearby.NearbyDistanceMeter) {
+	s.nearbyMeter = meter
+	s.nearbyRandom = nearby.NewNearbyRandom(&s.config.NearbySelectionConfig)
+}
+
+// buildNearbyMatrices 在阶段开始时重建距离矩阵，矩阵在阶段内按起点懒加载并缓存
+func (s *DefaultMoveSelector) buildNearbyMatrices(workingSolution api.ISolution) {
+	if !s.isNearby() {
+		return
+	}
+	entities := s.getPlanningEntities(workingSolution)
+	destinations := make([]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		destinations = append(destinations, entity)
+	}
+	s.entityMatrix = nearby.NewNearbyDistanceMatrix(s.nearbyMeter, func(origin interface{}) []interface{} {
+		return destinations
+	})
+	s.valueMatrices = make(map[int]*nearby.NearbyDistanceMatrix)
+}
+
+// isNearby 是否启用了就近选择，启用时求解器要求设置距离度量
+func (s *DefaultMoveSelector) isNearby() bool {
+	return s.config.NearbySelection && s.nearbyMeter != nil
+}
+
+// nearbyEntity 按距离加权选择起点附近的实体，没有候选实体时返回 false
+func (s *DefaultMoveSelector) nearbyEntity(workingSolution api.ISolution, origin api.IPlanningEntity) (api.IPlanningEntity, bool) {
+	if s.entityMatrix == nil {
+		s.buildNearbyMatrices(workingSolution)
+	}
+	size := s.entityMatrix.GetSize(origin)
+	if size == 0 {
+		return nil, false
+	}
+	index := s.nearbyRandom.NextInt(s.random, size)
+	return s.entityMatrix.GetDestination(origin, index).(api.IPlanningEntity), true
+}
+
+// nearbyValue 按距离加权选择实体第 variableIndex 个规划变量附近的值，没有候选值时返回 false
+func (s *DefaultMoveSelector) nearbyValue(workingSolution api.ISolution, origin api.IPlanningEntity, variableIndex int) (interface{}, bool) {
+	if s.valueMatrices == nil {
+		s.buildNearbyMatrices(workingSolution)
+	}
+	matrix, ok := s.valueMatrices[variableIndex]
+	if !ok {
+		matrix = nearby.NewNearbyDistanceMatrix(s.nearbyMeter, func(origin interface{}) []interface{} {
+			entity := origin.(api.IPlanningEntity)
+			return valuerange.ToSlice(valuerange.Of(entity, entity.GetPlanningVariables()[variableIndex]))
+		})
+		s.valueMatrices[variableIndex] = matrix
+	}
+	size := matrix.GetSize(origin)
+	if size == 0 {
+		return nil, false
+	}
+	return matrix.GetDestination(origin, s.nearbyRandom.NextInt(s.random, size)), true
+}
+
+// selectRandomChangeMove 随机选择实体与规划变量，将其变更为随机值
+// 启用就近选择时按与实体的距离加权选择目标值，否则在值范围内均匀选择
+func (s *DefaultMoveSelector) selectRandomChangeMove(ctx context.Context, workingSolution api.ISolution) api.IMove {
+	entities := s.getPlanningEntities(workingSolution)
+	if len(entities) == 0 {
+		return nil
+	}
+	maxAttempts := 10
+	for attempts := 0; attempts < maxAttempts; attempts++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		entity := entities[s.random.Intn(len(entities))]
+		variables := entity.GetPlanningVariables()
+		if len(variables) == 0 {
+			continue
+		}
+		variableIndex := s.random.Intn(len(variables))
+		variable := variables[variableIndex]
+		var value interface{}
+		var ok bool
+		if s.isNearby() {
+			value, ok = s.nearbyValue(workingSolution, entity, variableIndex)
+		} else {
+			value, ok = valuerange.RandomValue(valuerange.Of(entity, variable), s.random)
+		}
+		if !ok || solution.IsSameValue(value, variable.GetValue()) {
+			continue
+		}
+		move := NewChangeMove(entity, variable, value, s.scoreDirector)
+		if s.isFeasibleMove(ctx, workingSolution, move) {
+			return move
+		}
+	}
+	return nil
+}
+
+// randomSwapEntity 为交换移动选择第二个实体，启用就近选择时选择第一个实体附近的实体
+func (s *DefaultMoveSelector) randomSwapEntity(workingSolution api.ISolution, entities []api.IPlanningEntity, origin api.IPlanningEntity) (api.IPlanningEntity, bool) {
+	if s.isNearby() {
+		entity, ok := s.nearbyEntity(workingSolution, origin)
+		// 阶段开始后被固定的实体不参与移动
+		if !ok || solution.IsPinned(workingSolution, entity) {
+			return nil, false
+		}
+		return entity, true
+	}
+	entity := entities[s.random.Intn(len(entities))]
+	return entity, entity != origin
+}
@@ -0,0 +1,184 @@
+package move
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+)
+
+// ParallelMoveEvaluator 在多个工作协程上评估移动
+// 每个工作协程拥有独立的克隆解决方案与分数指导器，结果按移动的顺序返回，合并方式与线程调度无关
+// 工作协程在阶段内首次评估时创建，之后每步通过 StepTaken 重放选中的移动，与工作解决方案保持一致
+type ParallelMoveEvaluator struct {
+	threadCount   int
+	scoreDirector api.IScoreDirector
+	// 当前阶段的工作协程及其克隆来源，为 nil 时在下次评估时重新创建
+	workers         []*moveWorker
+	workingSolution api.ISolution
+}
+
+func NewParallelMoveEvaluator(threadCount int, scoreDirector api.IScoreDirector) *ParallelMoveEvaluator {
+	return &ParallelMoveEvaluator{
+		threadCount:   threadCount,
+		scoreDirector: scoreDirector,
+	}
+}
+
+// 工作协程，在克隆的解决方案上评估移动
+type moveWorker struct {
+	solution      api.ISolution
+	scoreDirector api.IScoreDirector
+	lookup        *checkedLookup
+}
+
+// Supports 解决方案与分数指导器都可克隆时才能并行评估
+func (e *ParallelMoveEvaluator) Supports(workingSolution api.ISolution) bool {
+	if _, ok := workingSolution.(api.ICloneableSolution); !ok {
+		return false
+	}
+	_, ok := e.scoreDirector.(api.ICloneableScoreDirector)
+	return ok
+}
+
+// PhaseStarted 丢弃上一阶段的工作协程，阶段之间工作解决方案可能被移动以外的方式修改
+func (e *ParallelMoveEvaluator) PhaseStarted() {
+	e.workers = nil
+	e.workingSolution = nil
+}
+
+// StepTaken 在所有工作协程上重放工作解决方案上已执行的移动
+// 移动不可转移或转移时找不到对应的对象时丢弃工作协程，下次评估时从工作解决方案重新克隆
+func (e *ParallelMoveEvaluator) StepTaken(move api.IMove) {
+	if e.workers == nil {
+		return
+	}
+	rebasable, ok := move.(api.IRebasableMove)
+	if !ok {
+		e.PhaseStarted()
+		return
+	}
+	for _, worker := range e.workers {
+		rebased := worker.rebase(rebasable)
+		if rebased == nil {
+			e.PhaseStarted()
+			return
+		}
+		rebased.Execute(worker.solution)
+	}
+}
+
+// EvaluateMoves 评估所有移动，返回的得分与 moves 一一对应
+// 不可转移的移动在所有工作协程结束后于当前解决方案上评估，上下文取消时返回上下文的错误
+func (e *ParallelMoveEvaluator) EvaluateMoves(ctx context.Context, workingSolution api.ISolution, moves []api.IMove) ([]api.IScore, error) {
+	if e.workers == nil || e.workingSolution != workingSolution {
+		e.workers = make([]*moveWorker, 0, e.threadCount)
+		for i := 0; i < e.threadCount; i++ {
+			e.workers = append(e.workers, e.newWorker(workingSolution))
+		}
+		e.workingSolution = workingSolution
+	}
+	scores := make([]api.IScore, len(moves))
+	evaluated := make([]bool, len(moves))
+	workerCount := min(len(e.workers), len(moves))
+
+	errs := make([]error, workerCount)
+	var wg sync.WaitGroup
+	for w, worker := range e.workers[:workerCount] {
+		wg.Add(1)
+		go func(w int, worker *moveWorker) {
+			defer wg.Done()
+			// 按下标分配移动，每个移动总由同一个协程评估
+			for i := w; i < len(moves); i += workerCount {
+				rebasable, ok := moves[i].(api.IRebasableMove)
+				if !ok {
+					continue
+				}
+				rebased := worker.rebase(rebasable)
+				if rebased == nil {
+					continue
+				}
+				moveScore, err := worker.evaluate(ctx, rebased)
+				if err != nil {
+					errs[w] = err
+					return
+				}
+				scores[i] = moveScore
+				evaluated[i] = true
+			}
+		}(w, worker)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 在当前解决方案上评估不可转移的移动
+	for i, move := range moves {
+		if evaluated[i] {
+			continue
+		}
+		move.Execute(workingSolution)
+		moveScore, err := score.CalculateContext(ctx, e.scoreDirector, workingSolution)
+		move.Undo(workingSolution)
+		if err != nil {
+			return nil, err
+		}
+		scores[i] = moveScore
+	}
+	return scores, nil
+}
+
+func (e *ParallelMoveEvaluator) newWorker(workingSolution api.ISolution) *moveWorker {
+	clone := workingSolution.(api.ICloneableSolution).CloneSolution()
+	scoreDirector := e.scoreDirector.(api.ICloneableScoreDirector).CloneScoreDirector()
+	scoreDirector.SetWorkingSolution(clone)
+	return &moveWorker{
+		solution:      clone,
+		scoreDirector: scoreDirector,
+		lookup:        &checkedLookup{lookup: solution.NewWorkingObjectLookup(workingSolution, clone)},
+	}
+}
+
+// rebase 将移动转移到工作协程的克隆解决方案，任一对象找不到对应时返回 nil
+func (w *moveWorker) rebase(move api.IRebasableMove) api.IMove {
+	w.lookup.missed = false
+	rebased := move.Rebase(w.lookup, w.scoreDirector)
+	if rebased == nil || w.lookup.missed {
+		return nil
+	}
+	return rebased
+}
+
+func (w *moveWorker) evaluate(ctx context.Context, move api.IMove) (api.IScore, error) {
+	move.Execute(w.solution)
+	moveScore, err := score.CalculateContext(ctx, w.scoreDirector, w.solution)
+	move.Undo(w.solution)
+	return moveScore, err
+}
+
+// checkedLookup 记录是否有对象找不到对应，转移后的移动不能持有 nil 的实体或变量
+type checkedLookup struct {
+	lookup api.IWorkingObjectLookup
+	missed bool
+}
+
+func (l *checkedLookup) LookUpEntity(entity api.IPlanningEntity) api.IPlanningEntity {
+	found := l.lookup.LookUpEntity(entity)
+	if found == nil {
+		l.missed = true
+	}
+	return found
+}
+
+func (l *checkedLookup) LookUpVariable(variable api.IPlanningVariable) api.IPlanningVariable {
+	found := l.lookup.LookUpVariable(variable)
+	if found == nil {
+		l.missed = true
+	}
+	return found
+}
@@ -0,0 +1,73 @@
+package move
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// PillarChangeMove 将柱中所有实体的同一规划变量改为目标值，由每个实体的 ChangeMove 组成
+type PillarChangeMove struct {
+	pillar        []api.IPlanningEntity
+	variableIndex int
+	targetValue   interface{}
+	changeMoves   []*ChangeMove
+	scoreDirector api.IScoreDirector
+}
+
+func NewPillarChangeMove(pillar []api.IPlanningEntity, variableIndex int, targetValue interface{}, scoreDirector api.IScoreDirector) *PillarChangeMove {
+	changeMoves := make([]*ChangeMove, 0, len(pillar))
+	for _, entity := range pillar {
+		variable := entity.GetPlanningVariables()[variableIndex]
+		changeMoves = append(changeMoves, NewChangeMove(entity, variable, targetValue, scoreDirector))
+	}
+	return &PillarChangeMove{
+		pillar:        pillar,
+		variableIndex: variableIndex,
+		targetValue:   targetValue,
+		changeMoves:   changeMoves,
+		scoreDirector: scoreDirector,
+	}
+}
+
+func (m *PillarChangeMove) Execute(workingSolution api.ISolution) {
+	for _, move := range m.changeMoves {
+		move.Execute(workingSolution)
+	}
+}
+
+func (m *PillarChangeMove) Undo(workingSolution api.ISolution) {
+	for i := len(m.changeMoves) - 1; i >= 0; i-- {
+		m.changeMoves[i].Undo(workingSolution)
+	}
+}
+
+func (m *PillarChangeMove) Accept(scoreDirector api.IScoreDirector) bool {
+	return true
+}
+
+// Rebase 创建作用于目标解决方案的相同移动
+func (m *PillarChangeMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	return NewPillarChangeMove(lookUpEntities(lookup, m.pillar), m.variableIndex, m.targetValue, scoreDirector)
+}
+
+func (m *PillarChangeMove) String() string {
+	return fmt.Sprintf("PillarChangeMove(pillar=[%s], value=%v)", joinEntities(m.pillar), m.targetValue)
+}
+
+func lookUpEntities(lookup api.IWorkingObjectLookup, entities []api.IPlanningEntity) []api.IPlanningEntity {
+	rebased := make([]api.IPlanningEntity, 0, len(entities))
+	for _, entity := range entities {
+		rebased = append(rebased, lookup.LookUpEntity(entity))
+	}
+	return rebased
+}
+
+func joinEntities(entities []api.IPlanningEntity) string {
+	names := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		names = append(names, fmt.Sprint(entity))
+	}
+	return strings.Join(names, ", ")
+}
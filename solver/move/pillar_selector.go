@@ -0,0 +1,110 @@
+package move
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+)
+
+// PillarSelector 选择柱（同一规划变量取相同值的一组实体）及其子柱
+type PillarSelector struct {
+	config *config.PillarSelectorConfig
+	random *rand.Rand
+	// SEQUENCE 子柱中实体的顺序，为 nil 时使用实体在解决方案中的顺序
+	sequenceComparator api.IComparator[api.IPlanningEntity]
+}
+
+func NewPillarSelector(cfg *config.PillarSelectorConfig, random *rand.Rand) *PillarSelector {
+	return &PillarSelector{config: cfg, random: random}
+}
+
+// SetSequenceComparator 设置 SEQUENCE 子柱中实体的顺序
+func (p *PillarSelector) SetSequenceComparator(comparator api.IComparator[api.IPlanningEntity]) {
+	p.sequenceComparator = comparator
+}
+
+// GetPillars 按第 variableIndex 个规划变量的值将可移动实体分组，未赋值的实体不属于任何柱
+// 柱按值首次出现的顺序排列，小于最小子柱大小的柱被忽略
+// 值按 == 分组，值不可比较（如切片、映射）时返回错误
+func (p *PillarSelector) GetPillars(workingSolution api.ISolution, variableIndex int) ([][]api.IPlanningEntity, error) {
+	indexes := make(map[interface{}]int)
+	pillars := make([][]api.IPlanningEntity, 0)
+	for _, entity := range solution.GetMovablePlanningEntities(workingSolution) {
+		variables := entity.GetPlanningVariables()
+		if variableIndex >= len(variables) {
+			continue
+		}
+		value := variables[variableIndex].GetValue()
+		if value == nil {
+			continue
+		}
+		if t := reflect.TypeOf(value); !t.Comparable() {
+			return nil, fmt.Errorf("pillar selection requires comparable planning values, variable %d of entity %v has a value of type %s", variableIndex, entity, t)
+		}
+		index, ok := indexes[value]
+		if !ok {
+			index = len(pillars)
+			indexes[value] = index
+			pillars = append(pillars, nil)
+		}
+		pillars[index] = append(pillars[index], entity)
+	}
+	minSize := p.minimumSize()
+	result := make([][]api.IPlanningEntity, 0, len(pillars))
+	for _, pillar := range pillars {
+		if len(pillar) >= minSize {
+			result = append(result, pillar)
+		}
+	}
+	return result, nil
+}
+
+// RandomSubPillar 按子柱类型从柱中随机选择子柱
+func (p *PillarSelector) RandomSubPillar(pillar []api.IPlanningEntity) []api.IPlanningEntity {
+	switch p.config.SubPillarType {
+	case config.SubPillarTypeAll:
+		size := p.randomSize(len(pillar))
+		// 随机选出 size 个实体，保持柱中的顺序
+		indexes := p.random.Perm(len(pillar))[:size]
+		sort.Ints(indexes)
+		subPillar := make([]api.IPlanningEntity, 0, size)
+		for _, index := range indexes {
+			subPillar = append(subPillar, pillar[index])
+		}
+		return subPillar
+	case config.SubPillarTypeSequence:
+		sequence := append([]api.IPlanningEntity(nil), pillar...)
+		if p.sequenceComparator != nil {
+			sort.SliceStable(sequence, func(i, j int) bool {
+				return p.sequenceComparator.Compare(sequence[i], sequence[j]) < 0
+			})
+		}
+		size := p.randomSize(len(sequence))
+		start := p.random.Intn(len(sequence) - size + 1)
+		return sequence[start : start+size]
+	default:
+		return pillar
+	}
+}
+
+// randomSize 在 [最小子柱大小, min(最大子柱大小, 柱大小)] 中随机选择子柱大小
+func (p *PillarSelector) randomSize(pillarSize int) int {
+	maxSize := pillarSize
+	if p.config.MaximumSubPillarSize > 0 && p.config.MaximumSubPillarSize < maxSize {
+		maxSize = p.config.MaximumSubPillarSize
+	}
+	minSize := min(p.minimumSize(), maxSize)
+	return minSize + p.random.Intn(maxSize-minSize+1)
+}
+
+func (p *PillarSelector) minimumSize() int {
+	if p.config.MinimumSubPillarSize < 1 {
+		return 1
+	}
+	return p.config.MinimumSubPillarSize
+}
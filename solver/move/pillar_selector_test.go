@@ -0,0 +1,188 @@
+package move
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+type testVariable struct {
+	value interface{}
+}
+
+func (v *testVariable) GetValue() interface{}          { return v.value }
+func (v *testVariable) SetValue(value interface{})     { v.value = value }
+func (v *testVariable) GetValueRange() api.IValueRange { return nil }
+
+type testEntity struct {
+	id       int
+	variable *testVariable
+}
+
+func (e *testEntity) PlanningFilter() {}
+func (e *testEntity) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{e.variable}
+}
+
+type testSolution struct {
+	score    api.IScore
+	entities []api.IPlanningEntity
+}
+
+func (s *testSolution) GetScore() api.IScore                               { return s.score }
+func (s *testSolution) SetScore(score api.IScore)                          { s.score = score }
+func (s *testSolution) GetPlanningEntities() []api.IPlanningEntity         { return s.entities }
+func (s *testSolution) SetPlanningEntities(entities []api.IPlanningEntity) { s.entities = entities }
+func (s *testSolution) GetProblemFacts() []interface{}                     { return nil }
+func (s *testSolution) SetProblemFacts(facts []interface{})                {}
+
+func newTestSolution(values ...interface{}) *testSolution {
+	s := &testSolution{}
+	for i, value := range values {
+		s.entities = append(s.entities, &testEntity{id: i, variable: &testVariable{value: value}})
+	}
+	return s
+}
+
+// 按 id 递减排序的比较器
+type descendingID struct{}
+
+func (descendingID) Compare(a, b api.IPlanningEntity) int {
+	return b.(*testEntity).id - a.(*testEntity).id
+}
+
+func entityIDs(entities []api.IPlanningEntity) []int {
+	ids := make([]int, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.(*testEntity).id)
+	}
+	return ids
+}
+
+func TestGetPillarsGroupsByValueInOrderOfFirstAppearance(t *testing.T) {
+	selector := NewPillarSelector(&config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeAll, MinimumSubPillarSize: 2}, rand.New(rand.NewSource(0)))
+	pillars, err := selector.GetPillars(newTestSolution("b", "a", "b", nil, "a", "c", "b"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]int{{0, 2, 6}, {1, 4}}
+	if len(pillars) != len(want) {
+		t.Fatalf("got %d pillars, want %d", len(pillars), len(want))
+	}
+	for i, pillar := range pillars {
+		if got := entityIDs(pillar); !slices.Equal(got, want[i]) {
+			t.Errorf("pillar %d: got entities %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestGetPillarsRejectsNonComparableValues(t *testing.T) {
+	selector := NewPillarSelector(&config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeNone, MinimumSubPillarSize: 1}, rand.New(rand.NewSource(0)))
+	_, err := selector.GetPillars(newTestSolution([]int{1}, []int{1}), 0)
+	if err == nil || !strings.Contains(err.Error(), "comparable") {
+		t.Fatalf("got error %v, want an error about non-comparable values", err)
+	}
+}
+
+func TestRandomSubPillarAllSizing(t *testing.T) {
+	cfg := &config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeAll, MinimumSubPillarSize: 2, MaximumSubPillarSize: 4}
+	selector := NewPillarSelector(cfg, rand.New(rand.NewSource(0)))
+	pillar := newTestSolution(1, 1, 1, 1, 1, 1).GetPlanningEntities()
+
+	sizes := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		ids := entityIDs(selector.RandomSubPillar(pillar))
+		sizes[len(ids)]++
+		if len(ids) < cfg.MinimumSubPillarSize || len(ids) > cfg.MaximumSubPillarSize {
+			t.Fatalf("sub pillar %v has size %d, want between %d and %d", ids, len(ids), cfg.MinimumSubPillarSize, cfg.MaximumSubPillarSize)
+		}
+		for j := 1; j < len(ids); j++ {
+			if ids[j] <= ids[j-1] {
+				t.Fatalf("sub pillar %v does not keep the pillar order or repeats an entity", ids)
+			}
+		}
+	}
+	for size := cfg.MinimumSubPillarSize; size <= cfg.MaximumSubPillarSize; size++ {
+		if sizes[size] == 0 {
+			t.Errorf("size %d was never selected, got sizes %v", size, sizes)
+		}
+	}
+}
+
+func TestRandomSubPillarAllSizingIsCappedByPillarSize(t *testing.T) {
+	cfg := &config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeAll, MinimumSubPillarSize: 1}
+	selector := NewPillarSelector(cfg, rand.New(rand.NewSource(0)))
+	pillar := newTestSolution(1, 1, 1).GetPlanningEntities()
+
+	sizes := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		sizes[len(selector.RandomSubPillar(pillar))]++
+	}
+	for size := 1; size <= len(pillar); size++ {
+		if sizes[size] == 0 {
+			t.Errorf("size %d was never selected, got sizes %v", size, sizes)
+		}
+	}
+	if len(sizes) != len(pillar) {
+		t.Errorf("got sizes %v, want only sizes 1 to %d", sizes, len(pillar))
+	}
+}
+
+func TestRandomSubPillarSequenceSizing(t *testing.T) {
+	cfg := &config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeSequence, MinimumSubPillarSize: 2, MaximumSubPillarSize: 3}
+	selector := NewPillarSelector(cfg, rand.New(rand.NewSource(0)))
+	pillar := newTestSolution(1, 1, 1, 1, 1).GetPlanningEntities()
+
+	sizes := make(map[int]int)
+	starts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		ids := entityIDs(selector.RandomSubPillar(pillar))
+		sizes[len(ids)]++
+		starts[ids[0]]++
+		if len(ids) < cfg.MinimumSubPillarSize || len(ids) > cfg.MaximumSubPillarSize {
+			t.Fatalf("sub pillar %v has size %d, want between %d and %d", ids, len(ids), cfg.MinimumSubPillarSize, cfg.MaximumSubPillarSize)
+		}
+		for j := 1; j < len(ids); j++ {
+			if ids[j] != ids[j-1]+1 {
+				t.Fatalf("sub pillar %v is not a contiguous sequence", ids)
+			}
+		}
+	}
+	for size := cfg.MinimumSubPillarSize; size <= cfg.MaximumSubPillarSize; size++ {
+		if sizes[size] == 0 {
+			t.Errorf("size %d was never selected, got sizes %v", size, sizes)
+		}
+	}
+	// 大小为 2 时最后一个起点为 3
+	for start := 0; start <= 3; start++ {
+		if starts[start] == 0 {
+			t.Errorf("start %d was never selected, got starts %v", start, starts)
+		}
+	}
+}
+
+func TestRandomSubPillarSequenceUsesComparatorOrder(t *testing.T) {
+	cfg := &config.PillarSelectorConfig{SubPillarType: config.SubPillarTypeSequence, MinimumSubPillarSize: 3, MaximumSubPillarSize: 3}
+	selector := NewPillarSelector(cfg, rand.New(rand.NewSource(0)))
+	selector.SetSequenceComparator(descendingID{})
+	pillar := newTestSolution(1, 1, 1, 1).GetPlanningEntities()
+
+	for i := 0; i < 100; i++ {
+		ids := entityIDs(selector.RandomSubPillar(pillar))
+		if len(ids) != 3 {
+			t.Fatalf("got sub pillar %v, want size 3", ids)
+		}
+		for j := 1; j < len(ids); j++ {
+			if ids[j] != ids[j-1]-1 {
+				t.Fatalf("sub pillar %v is not contiguous in comparator order", ids)
+			}
+		}
+	}
+	if got := entityIDs(pillar); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("pillar was reordered to %v", got)
+	}
+}
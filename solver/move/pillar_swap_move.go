@@ -0,0 +1,60 @@
+package move
+
+import (
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// PillarSwapMove 交换两个柱的规划值，与 SwapMove 一样交换值，柱的大小可以不同
+type PillarSwapMove struct {
+	leftPillar    []api.IPlanningEntity
+	rightPillar   []api.IPlanningEntity
+	variableIndex int
+	changeMoves   []*ChangeMove
+	scoreDirector api.IScoreDirector
+}
+
+func NewPillarSwapMove(leftPillar, rightPillar []api.IPlanningEntity, variableIndex int, scoreDirector api.IScoreDirector) *PillarSwapMove {
+	return &PillarSwapMove{
+		leftPillar:    leftPillar,
+		rightPillar:   rightPillar,
+		variableIndex: variableIndex,
+		scoreDirector: scoreDirector,
+	}
+}
+
+func (m *PillarSwapMove) Execute(workingSolution api.ISolution) {
+	// 在执行时读取两个柱当前的值
+	leftValue := m.leftPillar[0].GetPlanningVariables()[m.variableIndex].GetValue()
+	rightValue := m.rightPillar[0].GetPlanningVariables()[m.variableIndex].GetValue()
+	m.changeMoves = make([]*ChangeMove, 0, len(m.leftPillar)+len(m.rightPillar))
+	for _, entity := range m.leftPillar {
+		m.changeMoves = append(m.changeMoves, NewChangeMove(entity, entity.GetPlanningVariables()[m.variableIndex], rightValue, m.scoreDirector))
+	}
+	for _, entity := range m.rightPillar {
+		m.changeMoves = append(m.changeMoves, NewChangeMove(entity, entity.GetPlanningVariables()[m.variableIndex], leftValue, m.scoreDirector))
+	}
+	for _, move := range m.changeMoves {
+		move.Execute(workingSolution)
+	}
+}
+
+func (m *PillarSwapMove) Undo(workingSolution api.ISolution) {
+	for i := len(m.changeMoves) - 1; i >= 0; i-- {
+		m.changeMoves[i].Undo(workingSolution)
+	}
+}
+
+func (m *PillarSwapMove) Accept(scoreDirector api.IScoreDirector) bool {
+	return true
+}
+
+// Rebase 创建作用于目标解决方案的相同移动
+func (m *PillarSwapMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	return NewPillarSwapMove(lookUpEntities(lookup, m.leftPillar), lookUpEntities(lookup, m.rightPillar), m.variableIndex, scoreDirector)
+}
+
+func (m *PillarSwapMove) String() string {
+	return fmt.Sprintf("PillarSwapMove(left=[%s], right=[%s])", joinEntities(m.leftPillar), joinEntities(m.rightPillar))
+}
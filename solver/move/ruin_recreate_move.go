@@ -0,0 +1,128 @@
+package move
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/heuristic"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+)
+
+// RuinRecreateMove 取消一组实体的赋值，再由嵌套的构造启发式重新赋值
+// 第一次执行时记录重建的结果，之后的执行直接重放，保证评估与执行得到相同的解
+type RuinRecreateMove struct {
+	entities      []api.IPlanningEntity
+	chConfig      *config.ConstructionHeuristicConfig
+	scoreDirector api.IScoreDirector
+	ctx           context.Context
+
+	variables       []api.IPlanningVariable
+	oldValues       []interface{}
+	recreatedValues []interface{}
+}
+
+func NewRuinRecreateMove(entities []api.IPlanningEntity, chConfig *config.ConstructionHeuristicConfig, scoreDirector api.IScoreDirector) *RuinRecreateMove {
+	move := &RuinRecreateMove{
+		entities:      entities,
+		chConfig:      chConfig,
+		scoreDirector: scoreDirector,
+		ctx:           context.Background(),
+	}
+	for _, entity := range entities {
+		move.variables = append(move.variables, entity.GetPlanningVariables()...)
+	}
+	return move
+}
+
+func (m *RuinRecreateMove) Execute(workingSolution api.ISolution) {
+	// 保存旧值，用于撤销
+	m.oldValues = make([]interface{}, len(m.variables))
+	for i, variable := range m.variables {
+		m.oldValues[i] = variable.GetValue()
+	}
+	if m.recreatedValues != nil {
+		m.setValues(m.recreatedValues)
+		return
+	}
+	// 破坏
+	m.setValues(make([]interface{}, len(m.variables)))
+	// 重建
+	constructionHeuristic := heuristic.NewConstructionHeuristic(m.chConfig, m.scoreDirector)
+	constructionHeuristic.SetEntities(m.entities)
+	if _, err := constructionHeuristic.Construct(m.ctx, workingSolution); err != nil {
+		// 构造启发式的类型在求解前已经校验，无法重建时恢复原值，移动不改变解决方案
+		m.setValues(m.oldValues)
+		return
+	}
+	recreated := make([]interface{}, len(m.variables))
+	for i, variable := range m.variables {
+		recreated[i] = variable.GetValue()
+	}
+	// 重建被取消时不记录结果
+	if m.ctx.Err() == nil {
+		m.recreatedValues = recreated
+	}
+}
+
+func (m *RuinRecreateMove) Undo(workingSolution api.ISolution) {
+	m.setValues(m.oldValues)
+}
+
+func (m *RuinRecreateMove) Accept(scoreDirector api.IScoreDirector) bool {
+	return true
+}
+
+// Rebase 创建作用于目标解决方案的相同移动，已记录的重建结果一并转移
+// 重建结果中的实体值（如链式变量的前一个实体）同样转移到目标解决方案，找不到对应的对象时返回 nil
+func (m *RuinRecreateMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	entities := make([]api.IPlanningEntity, 0, len(m.entities))
+	for _, entity := range m.entities {
+		rebased := lookup.LookUpEntity(entity)
+		if rebased == nil {
+			return nil
+		}
+		entities = append(entities, rebased)
+	}
+	var recreatedValues []interface{}
+	if m.recreatedValues != nil {
+		recreatedValues = make([]interface{}, len(m.recreatedValues))
+		for i, value := range m.recreatedValues {
+			entity, ok := value.(api.IPlanningEntity)
+			if !ok {
+				recreatedValues[i] = value
+				continue
+			}
+			rebased := lookup.LookUpEntity(entity)
+			if rebased == nil {
+				return nil
+			}
+			recreatedValues[i] = rebased
+		}
+	}
+	rebased := NewRuinRecreateMove(entities, m.chConfig, scoreDirector)
+	rebased.ctx = m.ctx
+	rebased.recreatedValues = recreatedValues
+	return rebased
+}
+
+func (m *RuinRecreateMove) String() string {
+	entities := make([]string, 0, len(m.entities))
+	for _, entity := range m.entities {
+		entities = append(entities, fmt.Sprint(entity))
+	}
+	return fmt.Sprintf("RuinRecreateMove(entities=[%s])", strings.Join(entities, ", "))
+}
+
+func (m *RuinRecreateMove) setValues(values []interface{}) {
+	for i, variable := range m.variables {
+		if solution.IsSameValue(variable.GetValue(), values[i]) {
+			continue
+		}
+		m.scoreDirector.BeforeVariableChanged(variable)
+		variable.SetValue(values[i])
+		m.scoreDirector.AfterVariableChanged(variable)
+	}
+}
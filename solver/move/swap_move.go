@@ -1,6 +1,10 @@
 package move
 
-import "github.com/kruily/go-timefold-solver/solver/api"
+import (
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
 
 type SwapMove struct {
 	entity1       api.IPlanningEntity
@@ -38,3 +42,14 @@ func (m *SwapMove) Undo(workingSolution api.ISolution) {
 func (m *SwapMove) Accept(scoreDirector api.IScoreDirector) bool {
 	return true
 }
+
+func (m *SwapMove) String() string {
+	return fmt.Sprintf("SwapMove(entity1=%v, value1=%v, entity2=%v, value2=%v)",
+		m.entity1, m.variable1.GetValue(), m.entity2, m.variable2.GetValue())
+}
+
+// Rebase 创建作用于目标解决方案的相同移动
+func (m *SwapMove) Rebase(lookup api.IWorkingObjectLookup, scoreDirector api.IScoreDirector) api.IMove {
+	return NewSwapMove(lookup.LookUpEntity(m.entity1), lookup.LookUpEntity(m.entity2),
+		lookup.LookUpVariable(m.variable1), lookup.LookUpVariable(m.variable2), scoreDirector)
+}
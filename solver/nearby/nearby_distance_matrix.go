@@ -0,0 +1,78 @@
+package nearby
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/kruily/go-timefold-solver/solver/solution"
+)
+
+// NearbyDistanceMatrix 缓存每个起点按距离升序排列的终点
+// 每个起点的排序在第一次使用时计算，同一阶段内重复使用，不可比较的起点（如切片、映射）不缓存
+type NearbyDistanceMatrix struct {
+	meter NearbyDistanceMeter
+	// 获取起点的候选终点
+	destinations func(origin interface{}) []interface{}
+
+	mu     sync.Mutex
+	sorted map[interface{}][]interface{}
+}
+
+func NewNearbyDistanceMatrix(meter NearbyDistanceMeter, destinations func(origin interface{}) []interface{}) *NearbyDistanceMatrix {
+	return &NearbyDistanceMatrix{
+		meter:        meter,
+		destinations: destinations,
+		sorted:       make(map[interface{}][]interface{}),
+	}
+}
+
+// GetDestination 获取距离起点第 nearbyIndex 近的终点
+func (m *NearbyDistanceMatrix) GetDestination(origin interface{}, nearbyIndex int) interface{} {
+	return m.getSorted(origin)[nearbyIndex]
+}
+
+// GetSize 获取起点的候选终点数
+func (m *NearbyDistanceMatrix) GetSize(origin interface{}) int {
+	return len(m.getSorted(origin))
+}
+
+func (m *NearbyDistanceMatrix) getSorted(origin interface{}) []interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := reflect.TypeOf(origin)
+	cacheable := t == nil || t.Comparable()
+	if cacheable {
+		if sorted, ok := m.sorted[origin]; ok {
+			return sorted
+		}
+	}
+	candidates := m.destinations(origin)
+	sorted := make([]interface{}, 0, len(candidates))
+	distances := make([]float64, 0, len(candidates))
+	for _, destination := range candidates {
+		// 起点本身不是候选终点
+		if solution.IsSameValue(destination, origin) {
+			continue
+		}
+		sorted = append(sorted, destination)
+		distances = append(distances, m.meter.GetNearbyDistance(origin, destination))
+	}
+	sort.Stable(&byDistance{destinations: sorted, distances: distances})
+	if cacheable {
+		m.sorted[origin] = sorted
+	}
+	return sorted
+}
+
+type byDistance struct {
+	destinations []interface{}
+	distances    []float64
+}
+
+func (b *byDistance) Len() int           { return len(b.destinations) }
+func (b *byDistance) Less(i, j int) bool { return b.distances[i] < b.distances[j] }
+func (b *byDistance) Swap(i, j int) {
+	b.destinations[i], b.destinations[j] = b.destinations[j], b.destinations[i]
+	b.distances[i], b.distances[j] = b.distances[j], b.distances[i]
+}
@@ -0,0 +1,14 @@
+package nearby
+
+// NearbyDistanceMeter 计算起点到终点的距离，由用户提供
+// 起点为规划实体，终点为规划实体或规划值；距离不要求对称，但必须非负
+type NearbyDistanceMeter interface {
+	GetNearbyDistance(origin, destination interface{}) float64
+}
+
+// NearbyDistanceMeterFunc 将函数适配为 NearbyDistanceMeter
+type NearbyDistanceMeterFunc func(origin, destination interface{}) float64
+
+func (f NearbyDistanceMeterFunc) GetNearbyDistance(origin, destination interface{}) float64 {
+	return f(origin, destination)
+}
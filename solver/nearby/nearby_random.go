@@ -0,0 +1,127 @@
+package nearby
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+// NearbyRandom 按距离加权的概率分布选择终点的下标，下标越小距离越近
+type NearbyRandom interface {
+	// NextInt 返回 [0, nearbySize) 中的下标
+	NextInt(random *rand.Rand, nearbySize int) int
+}
+
+// BlockDistributionNearbyRandom 在最近的一块终点中均匀选择
+// 块大小为 nearbySize*SizeRatio，限制在 [SizeMinimum, SizeMaximum] 内
+// 以 UniformDistributionProbability 的概率在所有终点中均匀选择
+type BlockDistributionNearbyRandom struct {
+	SizeMinimum                    int
+	SizeMaximum                    int
+	SizeRatio                      float64
+	UniformDistributionProbability float64
+}
+
+func (b *BlockDistributionNearbyRandom) NextInt(random *rand.Rand, nearbySize int) int {
+	if b.UniformDistributionProbability > 0 && random.Float64() < b.UniformDistributionProbability {
+		return random.Intn(nearbySize)
+	}
+	size := nearbySize
+	if b.SizeRatio > 0 {
+		size = int(float64(nearbySize) * b.SizeRatio)
+	}
+	if b.SizeMaximum > 0 && size > b.SizeMaximum {
+		size = b.SizeMaximum
+	}
+	if size < b.SizeMinimum {
+		size = b.SizeMinimum
+	}
+	size = min(max(size, 1), nearbySize)
+	return random.Intn(size)
+}
+
+// LinearDistributionNearbyRandom 概率随下标线性递减，只选择最近的 SizeMaximum 个终点
+type LinearDistributionNearbyRandom struct {
+	SizeMaximum int
+}
+
+func (l *LinearDistributionNearbyRandom) NextInt(random *rand.Rand, nearbySize int) int {
+	m := limitSize(l.SizeMaximum, nearbySize)
+	// 概率密度 p(x) = 2(m-x)/m² 的逆累积分布
+	index := int(float64(m) * (1 - math.Sqrt(1-random.Float64())))
+	return min(index, m-1)
+}
+
+// ParabolicDistributionNearbyRandom 概率随下标按抛物线递减，只选择最近的 SizeMaximum 个终点
+type ParabolicDistributionNearbyRandom struct {
+	SizeMaximum int
+}
+
+func (p *ParabolicDistributionNearbyRandom) NextInt(random *rand.Rand, nearbySize int) int {
+	m := limitSize(p.SizeMaximum, nearbySize)
+	// 概率密度 p(x) = 3(m-x)²/m³ 的逆累积分布
+	index := int(float64(m) * (1 - math.Cbrt(1-random.Float64())))
+	return min(index, m-1)
+}
+
+// BetaDistributionNearbyRandom 按 Beta(Alpha, Beta) 分布选择，Alpha 小于 Beta 时偏向近的终点
+type BetaDistributionNearbyRandom struct {
+	Alpha float64
+	Beta  float64
+}
+
+func (b *BetaDistributionNearbyRandom) NextInt(random *rand.Rand, nearbySize int) int {
+	x := nextGamma(random, b.Alpha)
+	y := nextGamma(random, b.Beta)
+	index := int(x / (x + y) * float64(nearbySize))
+	return min(index, nearbySize-1)
+}
+
+// nextGamma 按 Marsaglia-Tsang 方法生成 Gamma(shape, 1) 分布的随机数
+func nextGamma(random *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// Gamma(a) = Gamma(a+1) * U^(1/a)
+		return nextGamma(random, shape+1) * math.Pow(random.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := random.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := random.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+func limitSize(sizeMaximum, nearbySize int) int {
+	if sizeMaximum > 0 && sizeMaximum < nearbySize {
+		return sizeMaximum
+	}
+	return nearbySize
+}
+
+// NewNearbyRandom 根据就近选择配置创建概率分布，默认为不限制大小的线性分布
+func NewNearbyRandom(cfg *config.NearbySelectionConfig) NearbyRandom {
+	switch cfg.DistributionType {
+	case config.NearbyDistributionBlock:
+		return &BlockDistributionNearbyRandom{
+			SizeMinimum:                    cfg.BlockSizeMinimum,
+			SizeMaximum:                    cfg.BlockSizeMaximum,
+			SizeRatio:                      cfg.BlockSizeRatio,
+			UniformDistributionProbability: cfg.BlockUniformDistributionProbability,
+		}
+	case config.NearbyDistributionParabolic:
+		return &ParabolicDistributionNearbyRandom{SizeMaximum: cfg.ParabolicSizeMaximum}
+	case config.NearbyDistributionBeta:
+		return &BetaDistributionNearbyRandom{Alpha: cfg.BetaAlpha, Beta: cfg.BetaBeta}
+	default:
+		return &LinearDistributionNearbyRandom{SizeMaximum: cfg.LinearSizeMaximum}
+	}
+}
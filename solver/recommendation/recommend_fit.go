@@ -0,0 +1,204 @@
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+// Recommendation 为实体赋予某个值的提议
+type Recommendation struct {
+	// 提议的值
+	Value interface{}
+	// 赋值后的分数
+	Score api.IScore
+	// 赋值后的分数减去赋值前的分数
+	ScoreDiff api.IScore
+	// 每个约束的分数变化，只在启用 WithConstraintBreakdown 时计算
+	ConstraintDiffs []ConstraintDiff
+}
+
+// ConstraintDiff 单个约束因赋值产生的分数变化
+type ConstraintDiff struct {
+	ConstraintName string
+	// 赋值前后是否匹配
+	MatchedBefore bool
+	MatchedAfter  bool
+	ScoreDiff     api.IScore
+}
+
+// Option 推荐选项
+type Option func(*recommender)
+
+type recommender struct {
+	variableIndex     int
+	hasVariableIndex  bool
+	constraintManager api.IConstraintConfigure
+	breakdown         bool
+}
+
+// WithVariableIndex 指定为实体的第 index 个规划变量推荐值，默认为第一个未赋值的变量
+func WithVariableIndex(index int) Option {
+	return func(r *recommender) {
+		r.variableIndex = index
+		r.hasVariableIndex = true
+	}
+}
+
+// WithConstraintBreakdown 计算每个约束的分数变化
+// constraintManager 为 nil 时使用分数指导器的约束配置，分数指导器需要提供 GetConstraintManager
+func WithConstraintBreakdown(constraintManager api.IConstraintConfigure) Option {
+	return func(r *recommender) {
+		r.breakdown = true
+		r.constraintManager = constraintManager
+	}
+}
+
+// 能够提供约束配置的分数指导器
+type constraintManagerProvider interface {
+	GetConstraintManager() api.IConstraintConfigure
+}
+
+// RecommendFit 为已求解的解决方案中未赋值的实体逐个尝试值范围内的值，按赋值后的分数从高到低返回提议
+// 解决方案需要实现 api.ICloneableSolution，在克隆上评估，不修改输入
+// 分数指导器实现 api.ICloneableScoreDirector 时使用克隆的分数指导器，否则返回前恢复其工作解决方案
+func RecommendFit(ctx context.Context, scoreDirector api.IScoreDirector, workingSolution api.ISolution, entity api.IPlanningEntity, options ...Option) ([]*Recommendation, error) {
+	r := &recommender{}
+	for _, option := range options {
+		option(r)
+	}
+	if r.breakdown && r.constraintManager == nil {
+		provider, ok := scoreDirector.(constraintManagerProvider)
+		if !ok {
+			return nil, fmt.Errorf("constraint breakdown requires a constraint manager, score director %T does not provide one", scoreDirector)
+		}
+		r.constraintManager = provider.GetConstraintManager()
+	}
+	if !containsEntity(workingSolution, entity) {
+		return nil, errors.New("entity does not belong to the solution")
+	}
+
+	// 在克隆上评估以免修改输入
+	cloneable, ok := workingSolution.(api.ICloneableSolution)
+	if !ok {
+		return nil, fmt.Errorf("recommend fit requires a cloneable solution, %T does not implement api.ICloneableSolution", workingSolution)
+	}
+	clone := cloneable.CloneSolution()
+	entity = solution.NewWorkingObjectLookup(workingSolution, clone).LookUpEntity(entity)
+	if entity == nil {
+		return nil, errors.New("entity not found in the cloned solution, CloneSolution must keep the entity order")
+	}
+	workingSolution = clone
+	if cloneable, ok := scoreDirector.(api.ICloneableScoreDirector); ok {
+		scoreDirector = cloneable.CloneScoreDirector()
+	} else {
+		previous := scoreDirector.GetWorkingSolution()
+		defer scoreDirector.SetWorkingSolution(previous)
+	}
+	scoreDirector.SetWorkingSolution(workingSolution)
+
+	variable, err := r.variableOf(entity)
+	if err != nil {
+		return nil, err
+	}
+	return r.recommend(ctx, scoreDirector, workingSolution, entity, variable)
+}
+
+func (r *recommender) recommend(ctx context.Context, scoreDirector api.IScoreDirector, workingSolution api.ISolution, entity api.IPlanningEntity, variable api.IPlanningVariable) ([]*Recommendation, error) {
+	beforeScore, err := score.CalculateContext(ctx, scoreDirector, workingSolution)
+	if err != nil {
+		return nil, err
+	}
+	var before *score.ScoreExplanation
+	if r.breakdown {
+		if before, err = score.ExplainScore(r.constraintManager, workingSolution); err != nil {
+			return nil, err
+		}
+	}
+
+	values := valuerange.ToSlice(valuerange.Of(entity, variable))
+	recommendations := make([]*Recommendation, 0, len(values))
+	for _, value := range values {
+		scoreDirector.BeforeVariableChanged(variable)
+		variable.SetValue(value)
+		scoreDirector.AfterVariableChanged(variable)
+
+		afterScore, err := score.CalculateContext(ctx, scoreDirector, workingSolution)
+		var after *score.ScoreExplanation
+		if err == nil && r.breakdown {
+			after, err = score.ExplainScore(r.constraintManager, workingSolution)
+		}
+
+		scoreDirector.BeforeVariableChanged(variable)
+		variable.SetValue(nil)
+		scoreDirector.AfterVariableChanged(variable)
+		if err != nil {
+			return nil, err
+		}
+
+		recommendation := &Recommendation{
+			Value:     value,
+			Score:     afterScore,
+			ScoreDiff: afterScore.Subtract(beforeScore),
+		}
+		if r.breakdown {
+			recommendation.ConstraintDiffs = constraintDiffs(before, after)
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score.CompareTo(recommendations[j].Score) > 0
+	})
+	return recommendations, nil
+}
+
+// variableOf 获取要推荐值的变量，变量必须未赋值
+func (r *recommender) variableOf(entity api.IPlanningEntity) (api.IPlanningVariable, error) {
+	variables := entity.GetPlanningVariables()
+	if r.hasVariableIndex {
+		if r.variableIndex < 0 || r.variableIndex >= len(variables) {
+			return nil, fmt.Errorf("variable index %d out of range, entity has %d variables", r.variableIndex, len(variables))
+		}
+		variable := variables[r.variableIndex]
+		if variable.GetValue() != nil {
+			return nil, fmt.Errorf("variable %d of the entity is already assigned", r.variableIndex)
+		}
+		return variable, nil
+	}
+	for _, variable := range variables {
+		if variable.GetValue() == nil {
+			return variable, nil
+		}
+	}
+	return nil, errors.New("entity has no uninitialized variable")
+}
+
+// constraintDiffs 按约束比较赋值前后的分数
+func constraintDiffs(before, after *score.ScoreExplanation) []ConstraintDiff {
+	diffs := make([]ConstraintDiff, 0, len(after.Constraints))
+	for i, c := range after.Constraints {
+		b := before.Constraints[i]
+		diffs = append(diffs, ConstraintDiff{
+			ConstraintName: c.ConstraintName,
+			MatchedBefore:  b.Matched,
+			MatchedAfter:   c.Matched,
+			ScoreDiff:      c.Score.Subtract(b.Score),
+		})
+	}
+	return diffs
+}
+
+func containsEntity(workingSolution api.ISolution, entity api.IPlanningEntity) bool {
+	for _, e := range solution.GetPlanningEntities(workingSolution) {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
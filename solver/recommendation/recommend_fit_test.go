@@ -0,0 +1,65 @@
+package recommendation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+)
+
+// 第 2 列的皇后未放置，放在第 0 行时得到无冲突的解
+const problem = `{"n": 4, "rows": [1, 3, null, 2]}`
+
+func decodeBoard(t *testing.T) api.ISolution {
+	t.Helper()
+	board, err := nqueens.Codec{}.DecodeProblem(strings.NewReader(problem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return board
+}
+
+func TestRecommendFitOrdersValuesByScore(t *testing.T) {
+	board := decodeBoard(t)
+	entity := board.GetPlanningEntities()[2]
+
+	recommendations, err := RecommendFit(context.Background(), codec.NewScoreDirector(nqueens.Codec{}), board, entity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendations) != 4 {
+		t.Fatalf("got %d recommendations, want 4", len(recommendations))
+	}
+	if best := recommendations[0]; best.Value != 0 || !best.Score.IsFeasible() {
+		t.Errorf("got best recommendation %v with score %s, want row 0 without conflicts", best.Value, best.Score.ToShortString())
+	}
+	for i := 1; i < len(recommendations); i++ {
+		if recommendations[i].Score.CompareTo(recommendations[i-1].Score) > 0 {
+			t.Errorf("recommendation %d scores %s, better than %s before it", i, recommendations[i].Score.ToShortString(), recommendations[i-1].Score.ToShortString())
+		}
+	}
+	if _, ok := entity.(*nqueens.Queen).GetRow(); ok {
+		t.Error("recommend fit assigned the input entity")
+	}
+}
+
+// 只实现 api.ISolution，不可克隆
+type plainSolution struct {
+	api.ISolution
+}
+
+func TestRecommendFitRequiresCloneableSolution(t *testing.T) {
+	board := plainSolution{decodeBoard(t)}
+	entity := board.GetPlanningEntities()[2]
+
+	_, err := RecommendFit(context.Background(), codec.NewScoreDirector(nqueens.Codec{}), board, entity)
+	if err == nil || !strings.Contains(err.Error(), "api.ICloneableSolution") {
+		t.Fatalf("got error %v, want an error about the non-cloneable solution", err)
+	}
+	if _, ok := entity.(*nqueens.Queen).GetRow(); ok {
+		t.Error("recommend fit assigned the input entity")
+	}
+}
@@ -0,0 +1,262 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/solver"
+)
+
+// 默认的请求体大小上限
+const defaultMaxRequestBodySize = 10 << 20
+
+// HandlerOption 处理器选项
+type HandlerOption func(*Handler)
+
+// Handler 求解服务的 HTTP 处理器，问题与解决方案的格式由领域编解码器决定
+//
+//	POST   /problems                      提交问题，可通过 ?id= 指定问题 ID
+//	GET    /problems                      所有问题的状态
+//	GET    /problems/{id}                 问题的状态
+//	GET    /problems/{id}/solution        目前的最佳解决方案
+//	GET    /problems/{id}/score-analysis  最佳解决方案的分数明细，格式与 explain -json 相同
+//	POST   /problems/{id}/terminate       提前终止求解
+//	DELETE /problems/{id}                 终止问题，求解结束后移除
+type Handler struct {
+	manager            *solver.SolverManager
+	domain             codec.DomainCodec
+	maxRequestBodySize int64
+	newID              func() (string, error)
+	mux                *http.ServeMux
+}
+
+// NewHandler 创建求解服务的处理器，处理器不拥有求解管理器，关闭服务时由调用方关闭管理器
+func NewHandler(manager *solver.SolverManager, domain codec.DomainCodec, options ...HandlerOption) *Handler {
+	h := &Handler{
+		manager:            manager,
+		domain:             domain,
+		maxRequestBodySize: defaultMaxRequestBodySize,
+		newID:              randomID,
+		mux:                http.NewServeMux(),
+	}
+	for _, option := range options {
+		option(h)
+	}
+	h.mux.HandleFunc("POST /problems", h.submit)
+	h.mux.HandleFunc("GET /problems", h.list)
+	h.mux.HandleFunc("GET /problems/{id}", h.status)
+	h.mux.HandleFunc("GET /problems/{id}/solution", h.solution)
+	h.mux.HandleFunc("GET /problems/{id}/score-analysis", h.scoreAnalysis)
+	h.mux.HandleFunc("POST /problems/{id}/terminate", h.terminate)
+	h.mux.HandleFunc("DELETE /problems/{id}", h.remove)
+	return h
+}
+
+// WithMaxRequestBodySize 设置提交问题的请求体大小上限，默认为 10 MiB
+func WithMaxRequestBodySize(size int64) HandlerOption {
+	return func(h *Handler) {
+		h.maxRequestBodySize = size
+	}
+}
+
+// WithIDGenerator 设置未指定问题 ID 时的 ID 生成函数，默认生成随机的十六进制字符串
+func WithIDGenerator(newID func() string) HandlerOption {
+	return func(h *Handler) {
+		h.newID = func() (string, error) { return newID(), nil }
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// 问题状态的响应
+type statusResponse struct {
+	ProblemID             string `json:"problem_id"`
+	Status                string `json:"status"`
+	Score                 string `json:"score,omitempty"`
+	TerminationReason     string `json:"termination_reason,omitempty"`
+	TerminatedEarly       bool   `json:"terminated_early"`
+	SolvingDurationMillis int64  `json:"solving_duration_millis"`
+	Error                 string `json:"error,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) submit(w http.ResponseWriter, r *http.Request) {
+	problemID := r.URL.Query().Get("id")
+	if problemID == "" {
+		var err error
+		if problemID, err = h.newID(); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	problem, err := h.domain.DecodeProblem(http.MaxBytesReader(w, r.Body, h.maxRequestBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("decode problem: %w", err))
+		return
+	}
+	job, err := h.manager.Solve(problemID, problem)
+	switch {
+	case errors.Is(err, solver.ErrProblemExists):
+		writeError(w, http.StatusConflict, err)
+		return
+	case errors.Is(err, solver.ErrSolverManagerClosed):
+		writeError(w, http.StatusServiceUnavailable, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/problems/"+problemID)
+	writeJSON(w, http.StatusAccepted, newStatusResponse(job))
+}
+
+func (h *Handler) list(w http.ResponseWriter, _ *http.Request) {
+	jobs := h.manager.GetSolverJobs()
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].GetProblemID() < jobs[j].GetProblemID()
+	})
+	statuses := make([]statusResponse, 0, len(jobs))
+	for _, job := range jobs {
+		statuses = append(statuses, newStatusResponse(job))
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (h *Handler) status(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.lookUpJob(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, newStatusResponse(job))
+}
+
+func (h *Handler) solution(w http.ResponseWriter, r *http.Request) {
+	best, ok := h.lookUpBestSolution(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := h.domain.EncodeSolution(w, best); err != nil {
+		// 已经开始写入响应体，无法再修改状态码
+		fmt.Fprintf(w, "\n%v\n", err)
+	}
+}
+
+// scoreAnalysis 以 score.ScoreExplanation.WriteJSON 的格式输出最佳解决方案的分数明细
+func (h *Handler) scoreAnalysis(w http.ResponseWriter, r *http.Request) {
+	best, ok := h.lookUpBestSolution(w, r)
+	if !ok {
+		return
+	}
+	explanation, err := score.ExplainScore(constraint.ForSolution(h.domain.ConstraintConfigure(), best), best)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	// 先编码再写入，说明对象无法编码时仍可以返回错误状态码
+	var body bytes.Buffer
+	if err := explanation.WriteJSON(&body); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	body.WriteTo(w)
+}
+
+func (h *Handler) terminate(w http.ResponseWriter, r *http.Request) {
+	job, ok := h.lookUpJob(w, r)
+	if !ok {
+		return
+	}
+	job.TerminateEarly()
+	// 等待求解协程结束，响应中的状态即为最终状态
+	<-job.Done()
+	writeJSON(w, http.StatusOK, newStatusResponse(job))
+}
+
+func (h *Handler) remove(w http.ResponseWriter, r *http.Request) {
+	if !h.manager.RemoveSolverJob(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("problem %s not found", r.PathValue("id")))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) lookUpJob(w http.ResponseWriter, r *http.Request) (*solver.SolverJob, bool) {
+	problemID := r.PathValue("id")
+	job, ok := h.manager.GetSolverJob(problemID)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("problem %s not found", problemID))
+	}
+	return job, ok
+}
+
+// lookUpBestSolution 获取最佳解决方案，求解期间问题不可克隆时返回 409
+func (h *Handler) lookUpBestSolution(w http.ResponseWriter, r *http.Request) (api.ISolution, bool) {
+	job, ok := h.lookUpJob(w, r)
+	if !ok {
+		return nil, false
+	}
+	best, ok := job.GetBestSolution()
+	if !ok {
+		writeError(w, http.StatusConflict, fmt.Errorf("best solution of problem %s is not available until solving ends", job.GetProblemID()))
+	}
+	return best, ok
+}
+
+func newStatusResponse(job *solver.SolverJob) statusResponse {
+	response := statusResponse{
+		ProblemID:             job.GetProblemID(),
+		Status:                job.GetStatus().String(),
+		Score:                 scoreString(job.GetBestScore()),
+		TerminatedEarly:       job.IsTerminatedEarly(),
+		SolvingDurationMillis: job.GetSolvingDuration().Milliseconds(),
+	}
+	if reason := job.GetTerminationReason(); reason != solver.TerminationReasonNone {
+		response.TerminationReason = reason.String()
+	}
+	if err := job.GetError(); err != nil {
+		response.Error = err.Error()
+	}
+	return response
+}
+
+func scoreString(s api.IScore) string {
+	if s == nil {
+		return ""
+	}
+	return s.ToShortString()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate problem id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
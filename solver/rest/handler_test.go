@@ -0,0 +1,318 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/solver"
+)
+
+// 求解很快结束的配置
+func quickConfig() *config.SolverConfig {
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	cfg.Termination = config.TerminationConfig{StepCountLimit: 20}
+	return cfg
+}
+
+// 只能被终止的配置，破坏并重建总能选出移动，局部搜索不会提前结束
+func endlessConfig() *config.SolverConfig {
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	cfg.MoveSelector = config.MOVE_SELECTOR_RUIN_RECREATE
+	cfg.Termination = config.TerminationConfig{TimeLimit: 300}
+	return cfg
+}
+
+func newTestServer(t *testing.T, cfg *config.SolverConfig, managerOptions []solver.SolverManagerOption, options ...HandlerOption) (*httptest.Server, *solver.SolverManager) {
+	t.Helper()
+	domain := nqueens.Codec{}
+	manager, err := solver.NewSolverManager(cfg, func() api.IScoreDirector { return codec.NewScoreDirector(domain) }, managerOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewHandler(manager, domain, options...))
+	t.Cleanup(func() {
+		server.Close()
+		manager.Close()
+	})
+	return server, manager
+}
+
+// do 发送请求并把 JSON 响应解码到 body 中，返回状态码
+func do(t *testing.T, method, url, requestBody string, body interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(requestBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, body); err != nil {
+			t.Fatalf("%s %s: decode response: %v\n%s", method, url, err, data)
+		}
+	}
+	return resp.StatusCode
+}
+
+func waitForJob(t *testing.T, manager *solver.SolverManager, problemID string) {
+	t.Helper()
+	job, ok := manager.GetSolverJob(problemID)
+	if !ok {
+		t.Fatalf("problem %s was not submitted", problemID)
+	}
+	select {
+	case <-job.Done():
+	case <-time.After(30 * time.Second):
+		t.Fatalf("problem %s did not finish", problemID)
+	}
+}
+
+func TestSubmitAndGetStatus(t *testing.T) {
+	server, manager := newTestServer(t, quickConfig(), nil)
+
+	var submitted statusResponse
+	req, _ := http.NewRequest(http.MethodPost, server.URL+"/problems?id=six", strings.NewReader(`{"n": 6}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.NewDecoder(resp.Body).Decode(&submitted)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("submit: got status %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	if got := resp.Header.Get("Location"); got != "/problems/six" {
+		t.Errorf("submit: got location %q, want /problems/six", got)
+	}
+	if submitted.ProblemID != "six" {
+		t.Errorf("submit: got problem id %q, want six", submitted.ProblemID)
+	}
+
+	if code := do(t, http.MethodPost, server.URL+"/problems?id=six", `{"n": 6}`, nil); code != http.StatusConflict {
+		t.Errorf("duplicate submit: got status %d, want %d", code, http.StatusConflict)
+	}
+
+	waitForJob(t, manager, "six")
+	var status statusResponse
+	if code := do(t, http.MethodGet, server.URL+"/problems/six", "", &status); code != http.StatusOK {
+		t.Fatalf("status: got status %d, want %d", code, http.StatusOK)
+	}
+	if status.Status != "NOT_SOLVING" || status.Score == "" || status.TerminationReason == "" || status.Error != "" {
+		t.Errorf("status: got %+v, want an ended job with a score and a termination reason", status)
+	}
+
+	var statuses []statusResponse
+	if code := do(t, http.MethodGet, server.URL+"/problems", "", &statuses); code != http.StatusOK {
+		t.Fatalf("list: got status %d, want %d", code, http.StatusOK)
+	}
+	if len(statuses) != 1 || statuses[0].ProblemID != "six" {
+		t.Errorf("list: got %+v, want only problem six", statuses)
+	}
+}
+
+func TestSubmitGeneratesProblemID(t *testing.T) {
+	server, _ := newTestServer(t, quickConfig(), nil, WithIDGenerator(func() string { return "generated" }))
+
+	var submitted statusResponse
+	if code := do(t, http.MethodPost, server.URL+"/problems", `{"n": 4}`, &submitted); code != http.StatusAccepted {
+		t.Fatalf("got status %d, want %d", code, http.StatusAccepted)
+	}
+	if submitted.ProblemID != "generated" {
+		t.Errorf("got problem id %q, want generated", submitted.ProblemID)
+	}
+}
+
+func TestSubmitReportsIDGenerationError(t *testing.T) {
+	server, manager := newTestServer(t, quickConfig(), nil)
+	handler := server.Config.Handler.(*Handler)
+	handler.newID = func() (string, error) { return "", errors.New("entropy exhausted") }
+
+	var body errorResponse
+	if code := do(t, http.MethodPost, server.URL+"/problems", `{"n": 4}`, &body); code != http.StatusInternalServerError {
+		t.Fatalf("got status %d, want %d", code, http.StatusInternalServerError)
+	}
+	if !strings.Contains(body.Error, "entropy exhausted") {
+		t.Errorf("got error %q, want the generator error", body.Error)
+	}
+	if jobs := manager.GetSolverJobs(); len(jobs) != 0 {
+		t.Errorf("got %d jobs, want none", len(jobs))
+	}
+}
+
+func TestSubmitRejectsInvalidProblem(t *testing.T) {
+	server, _ := newTestServer(t, quickConfig(), nil)
+
+	var body errorResponse
+	if code := do(t, http.MethodPost, server.URL+"/problems", `{"n": 0}`, &body); code != http.StatusBadRequest {
+		t.Fatalf("got status %d, want %d", code, http.StatusBadRequest)
+	}
+	if !strings.Contains(body.Error, "n must be at least 1") {
+		t.Errorf("got error %q, want the decode error", body.Error)
+	}
+}
+
+func TestSubmitRejectsLargeRequestBody(t *testing.T) {
+	server, _ := newTestServer(t, quickConfig(), nil, WithMaxRequestBodySize(8))
+
+	if code := do(t, http.MethodPost, server.URL+"/problems", `{"n": 4, "rows": [1, 3, 0, 2]}`, nil); code != http.StatusBadRequest {
+		t.Errorf("got status %d, want %d", code, http.StatusBadRequest)
+	}
+}
+
+func TestUnknownProblem(t *testing.T) {
+	server, _ := newTestServer(t, quickConfig(), nil)
+
+	for _, request := range []struct{ method, path string }{
+		{http.MethodGet, "/problems/missing"},
+		{http.MethodGet, "/problems/missing/solution"},
+		{http.MethodGet, "/problems/missing/score-analysis"},
+		{http.MethodPost, "/problems/missing/terminate"},
+		{http.MethodDelete, "/problems/missing"},
+	} {
+		if code := do(t, request.method, server.URL+request.path, "", nil); code != http.StatusNotFound {
+			t.Errorf("%s %s: got status %d, want %d", request.method, request.path, code, http.StatusNotFound)
+		}
+	}
+}
+
+func TestGetSolution(t *testing.T) {
+	server, manager := newTestServer(t, quickConfig(), nil)
+	do(t, http.MethodPost, server.URL+"/problems?id=six", `{"n": 6}`, nil)
+	waitForJob(t, manager, "six")
+
+	var solution struct {
+		N     int    `json:"n"`
+		Rows  []*int `json:"rows"`
+		Score string `json:"score"`
+	}
+	if code := do(t, http.MethodGet, server.URL+"/problems/six/solution", "", &solution); code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", code, http.StatusOK)
+	}
+	if solution.N != 6 || len(solution.Rows) != 6 {
+		t.Fatalf("got n %d with %d rows, want 6 and 6", solution.N, len(solution.Rows))
+	}
+	for column, row := range solution.Rows {
+		if row == nil {
+			t.Errorf("queen of column %d is not placed", column)
+		}
+	}
+	if solution.Score == "" {
+		t.Error("solution has no score")
+	}
+}
+
+func TestGetScoreAnalysis(t *testing.T) {
+	server, manager := newTestServer(t, quickConfig(), nil)
+	do(t, http.MethodPost, server.URL+"/problems?id=six", `{"n": 6}`, nil)
+	waitForJob(t, manager, "six")
+
+	var status statusResponse
+	do(t, http.MethodGet, server.URL+"/problems/six", "", &status)
+	var analysis struct {
+		Score       string `json:"score"`
+		Constraints []struct {
+			Name        string `json:"name"`
+			Weight      int    `json:"weight"`
+			MatchWeight int    `json:"match_weight"`
+		} `json:"constraints"`
+	}
+	if code := do(t, http.MethodGet, server.URL+"/problems/six/score-analysis", "", &analysis); code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", code, http.StatusOK)
+	}
+	if analysis.Score != status.Score {
+		t.Errorf("got score %s, want the best score %s", analysis.Score, status.Score)
+	}
+	names := make([]string, 0, len(analysis.Constraints))
+	for _, c := range analysis.Constraints {
+		names = append(names, c.Name)
+		if c.Weight != -1 || c.MatchWeight < 0 {
+			t.Errorf("constraint %s: got weight %d and match weight %d", c.Name, c.Weight, c.MatchWeight)
+		}
+	}
+	if got := strings.Join(names, ", "); got != "row conflict, ascending diagonal conflict, descending diagonal conflict" {
+		t.Errorf("got constraints %s", got)
+	}
+}
+
+func TestTerminate(t *testing.T) {
+	server, manager := newTestServer(t, endlessConfig(), nil)
+	do(t, http.MethodPost, server.URL+"/problems?id=endless", `{"n": 16}`, nil)
+	job, _ := manager.GetSolverJob("endless")
+
+	var status statusResponse
+	if code := do(t, http.MethodPost, server.URL+"/problems/endless/terminate", "", &status); code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", code, http.StatusOK)
+	}
+	select {
+	case <-job.Done():
+	default:
+		t.Fatal("terminate responded before solving ended")
+	}
+	if status.Status != "NOT_SOLVING" || !status.TerminatedEarly || status.Error != "" {
+		t.Errorf("got %+v, want an ended job terminated early without error", status)
+	}
+	if _, ok := manager.GetSolverJob("endless"); !ok {
+		t.Error("terminated job was removed")
+	}
+}
+
+func TestRemoveWaitsForSolvingToEnd(t *testing.T) {
+	server, manager := newTestServer(t, endlessConfig(), nil)
+	do(t, http.MethodPost, server.URL+"/problems?id=endless", `{"n": 16}`, nil)
+	job, _ := manager.GetSolverJob("endless")
+
+	if code := do(t, http.MethodDelete, server.URL+"/problems/endless", "", nil); code != http.StatusNoContent {
+		t.Fatalf("got status %d, want %d", code, http.StatusNoContent)
+	}
+	select {
+	case <-job.Done():
+	default:
+		t.Fatal("remove responded before solving ended")
+	}
+	if code := do(t, http.MethodGet, server.URL+"/problems/endless", "", nil); code != http.StatusNotFound {
+		t.Errorf("status after remove: got status %d, want %d", code, http.StatusNotFound)
+	}
+	// 移除后可以使用相同的问题 ID 重新提交
+	if code := do(t, http.MethodPost, server.URL+"/problems?id=endless", `{"n": 4}`, nil); code != http.StatusAccepted {
+		t.Errorf("resubmit: got status %d, want %d", code, http.StatusAccepted)
+	}
+}
+
+func TestFinishedJobsAreEvicted(t *testing.T) {
+	server, manager := newTestServer(t, quickConfig(), []solver.SolverManagerOption{solver.WithFinishedJobRetention(10 * time.Millisecond)})
+	do(t, http.MethodPost, server.URL+"/problems?id=six", `{"n": 6}`, nil)
+	waitForJob(t, manager, "six")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := manager.GetSolverJob("six"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("finished job was not evicted")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if code := do(t, http.MethodGet, server.URL+"/problems/six", "", nil); code != http.StatusNotFound {
+		t.Errorf("got status %d, want %d", code, http.StatusNotFound)
+	}
+}
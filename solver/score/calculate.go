@@ -0,0 +1,30 @@
+package score
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// CalculateContext 使用分数指导器计算得分
+// 分数指导器支持取消时在计算过程中检查上下文，否则只在计算前检查
+// 分数指导器没有返回分数时（如约束的分数类型不一致）返回错误
+func CalculateContext(ctx context.Context, scoreDirector api.IScoreDirector, solution api.ISolution) (api.IScore, error) {
+	var result api.IScore
+	if director, ok := scoreDirector.(api.IContextScoreDirector); ok {
+		var err error
+		if result, err = director.CalculateContext(ctx, solution); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result = scoreDirector.Calculate(solution)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("score director %T returned no score, check that all constraints use the same score type", scoreDirector)
+	}
+	return result, nil
+}
@@ -3,16 +3,24 @@ package score
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
 )
 
 var (
 	ZERO           = NewHardSoftScore(0, 0, 0)
-	ONE_SOFT       = NewHardSoftScore(0, 1, 0)
-	ONE_HARD       = NewHardSoftScore(1, 0, 0)
-	MINUS_ONE_SOFT = NewHardSoftScore(0, -1, 0)
-	MINUS_ONE_HARD = NewHardSoftScore(-1, 0, 0)
+	ONE_SOFT       = NewHardSoftScore(0, 0, 1)
+	ONE_HARD       = NewHardSoftScore(0, 1, 0)
+	MINUS_ONE_SOFT = NewHardSoftScore(0, 0, -1)
+	MINUS_ONE_HARD = NewHardSoftScore(0, -1, 0)
+
+	// DEFINITION 按硬、软两个级别运算，与其他分数类型运算时以 *scoredef.ScoreTypeMismatchError panic
+	DEFINITION = scoredef.NewDefinition("HardSoftScore", 2, func(initScore int, levels []int) *HardSoftScore {
+		return ofUninitialized(initScore, levels[0], levels[1])
+	})
 )
 
 type HardSoftScore struct {
@@ -25,8 +33,42 @@ func NewHardSoftScore(initScore, hardScore, softScore int) *HardSoftScore {
 	return &HardSoftScore{hardScore: hardScore, softScore: softScore, initScore: initScore}
 }
 
-func ParseScore(score string) *HardSoftScore {
-	return nil
+// ParseScore 解析分数字符串，格式为 "0hard/-10soft" 或 "-2init/0hard/-10soft"
+func ParseScore(score string) (*HardSoftScore, error) {
+	levels := strings.Split(strings.TrimSpace(score), "/")
+	initScore := 0
+	if len(levels) == 3 {
+		value, err := parseLevel(levels[0], "init")
+		if err != nil {
+			return nil, fmt.Errorf("invalid score %q: %w", score, err)
+		}
+		initScore = value
+		levels = levels[1:]
+	}
+	if len(levels) != 2 {
+		return nil, fmt.Errorf("invalid score %q: expected format \"0hard/0soft\" or \"0init/0hard/0soft\"", score)
+	}
+	hardScore, err := parseLevel(levels[0], "hard")
+	if err != nil {
+		return nil, fmt.Errorf("invalid score %q: %w", score, err)
+	}
+	softScore, err := parseLevel(levels[1], "soft")
+	if err != nil {
+		return nil, fmt.Errorf("invalid score %q: %w", score, err)
+	}
+	return ofUninitialized(initScore, hardScore, softScore), nil
+}
+
+// parseLevel 解析带后缀的分数级别，如 "-10soft"
+func parseLevel(level, suffix string) (int, error) {
+	if !strings.HasSuffix(level, suffix) {
+		return 0, fmt.Errorf("level %q must end with %q", level, suffix)
+	}
+	value, err := strconv.Atoi(strings.TrimSuffix(level, suffix))
+	if err != nil {
+		return 0, fmt.Errorf("level %q is not an integer", level)
+	}
+	return value, nil
 }
 
 func ofUninitialized(initScore, hardScore, softScore int) *HardSoftScore {
@@ -52,7 +94,7 @@ func of(hardScore, softScore int) *HardSoftScore {
 			return ONE_HARD
 		}
 	}
-	return NewHardSoftScore(0, hardScore, 0)
+	return NewHardSoftScore(0, hardScore, softScore)
 }
 
 func ofHard(hardScore int) *HardSoftScore {
@@ -94,59 +136,31 @@ func (h *HardSoftScore) IsFeasible() bool {
 }
 
 func (h *HardSoftScore) CompareTo(other api.IScore) int {
-	otherScore := other.(*HardSoftScore)
-	if h.initScore != otherScore.initScore {
-		return h.initScore - otherScore.initScore
-	}
-	if h.hardScore != otherScore.hardScore {
-		return h.hardScore - otherScore.hardScore
-	}
-	return h.softScore - otherScore.softScore
+	return DEFINITION.MustCompare(h, other)
 }
 
 func (h *HardSoftScore) WithInitScore(score int) api.IScore {
-	h.initScore = score
-	return h
+	return ofUninitialized(score, h.hardScore, h.softScore)
 }
 
 func (h *HardSoftScore) Add(other api.IScore) api.IScore {
-	return ofUninitialized(
-		h.initScore+other.InitScore(),
-		h.hardScore+other.(*HardSoftScore).HardScore(),
-		h.softScore+other.(*HardSoftScore).SoftScore(),
-	)
+	return DEFINITION.MustAdd(h, other)
 }
 
 func (h *HardSoftScore) Subtract(other api.IScore) api.IScore {
-	return ofUninitialized(
-		h.initScore-other.InitScore(),
-		h.hardScore-other.(*HardSoftScore).HardScore(),
-		h.softScore-other.(*HardSoftScore).SoftScore(),
-	)
+	return DEFINITION.MustSubtract(h, other)
 }
 
 func (h *HardSoftScore) Multiply(multiplicand float64) api.IScore {
-	return ofUninitialized(
-		h.initScore*int(multiplicand),
-		h.hardScore*int(multiplicand),
-		h.softScore*int(multiplicand),
-	)
+	return DEFINITION.Multiply(h, multiplicand)
 }
 
 func (h *HardSoftScore) Divide(divisor float64) api.IScore {
-	return ofUninitialized(
-		h.initScore/int(divisor),
-		h.hardScore/int(divisor),
-		h.softScore/int(divisor),
-	)
+	return DEFINITION.Divide(h, divisor)
 }
 
 func (h *HardSoftScore) Power(exponent float64) api.IScore {
-	return ofUninitialized(
-		h.initScore*int(exponent),
-		h.hardScore*int(exponent),
-		h.softScore*int(exponent),
-	)
+	return DEFINITION.Power(h, exponent)
 }
 
 func (h *HardSoftScore) Abs() api.IScore {
@@ -182,7 +196,7 @@ func (h *HardSoftScore) ToLevelDoubles() []float64 {
 }
 
 func (h *HardSoftScore) IsSolutionInitailized() bool {
-	return h.initScore != 0
+	return h.initScore >= 0
 }
 
 func (h *HardSoftScore) ToShortString() string {
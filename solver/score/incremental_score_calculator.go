@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
 	"github.com/kruily/go-timefold-solver/solver/solution"
 )
 
@@ -45,14 +46,10 @@ func (c *IncrementalScoreCalculator) AfterVariableChange(variable api.IPlanningV
 }
 
 func (c *IncrementalScoreCalculator) findEntityForVariable(variable api.IPlanningVariable) api.IPlanningEntity {
-	facts := c.solution.GetProblemFacts()
-
-	for _, fact := range facts {
-		if entity, ok := fact.(api.IPlanningEntity); ok {
-			for _, v := range entity.GetPlanningVariables() {
-				if v == variable {
-					return entity
-				}
+	for _, entity := range solution.GetPlanningEntities(c.solution) {
+		for _, v := range entity.GetPlanningVariables() {
+			if v == variable {
+				return entity
 			}
 		}
 	}
@@ -74,25 +71,18 @@ func (c *IncrementalScoreCalculator) recalculateAffectedConstraints() {
 
 func (c *IncrementalScoreCalculator) calulateScoreForDirtyEntities() api.IScore {
 	sub := c.createSubSolution()
-	var totalScore api.IScore
-
-	for _, constraint := range c.constraintManager.GetConstraints() {
-		if constraint.Match(sub) {
-			score := constraint.GetScore()
-			if totalScore == nil {
-				totalScore = score
-			} else {
-				// 假设分数实现了 Add 方法
-				if hardSoftScore, ok := score.(*HardSoftScore); ok {
-					totalScore = hardSoftScore.Add(hardSoftScore)
-				}
-			}
-		}
-	}
-	if totalScore == nil {
-		return NewHardSoftScore(0, 0, 0)
+	constraints := c.constraintManager.GetConstraints()
+	// 约束的分数类型不一致时没有分数，求解器在创建时已检查
+	totalScore, err := zeroScore(constraints)
+	if err != nil {
+		return nil
 	}
 
+	for _, constr := range constraints {
+		if matchWeight := constraint.MatchWeightOf(constr, sub); matchWeight != 0 {
+			totalScore = totalScore.Add(constr.GetScore().Multiply(float64(constr.GetWeight() * matchWeight)))
+		}
+	}
 	return totalScore
 }
 
@@ -104,3 +94,12 @@ func (c *IncrementalScoreCalculator) clearDirtyFlags() {
 	c.dirtyEntities = make(map[api.IPlanningEntity]struct{})
 	c.dirtyVars = make(map[api.IPlanningVariable]struct{})
 }
+
+// Reset 在工作解决方案被结构性修改（如增删实体）后丢弃增量状态，以从头计算的分数作为缓存
+func (c *IncrementalScoreCalculator) Reset(workingSolution api.ISolution, score api.IScore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.solution = workingSolution
+	c.scoreCache = score
+	c.clearDirtyFlags()
+}
@@ -0,0 +1,154 @@
+package score
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+)
+
+// ScoreSnapshot 某一时刻从头计算的分数与各约束的匹配结果
+type ScoreSnapshot struct {
+	Score   api.IScore
+	Matches []ConstraintMatch
+}
+
+// ConstraintMatch 单个约束的匹配结果
+type ConstraintMatch struct {
+	ConstraintName string
+	Matched        bool
+	MatchWeight    int
+}
+
+// ScoreCorruptionError 分数损坏错误，说明触发损坏的移动以及匹配结果不一致的约束
+type ScoreCorruptionError struct {
+	// 触发损坏的移动，阶段结束时的检查为空
+	Move string
+	// 检查的阶段，如 "after move"、"after undo"
+	Stage string
+	// 期望的分数
+	ExpectedScore api.IScore
+	// 实际的分数
+	ActualScore api.IScore
+	// 匹配结果不一致的约束
+	DivergedConstraints []string
+}
+
+func (e *ScoreCorruptionError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "score corruption %s", e.Stage)
+	if e.Move != "" {
+		fmt.Fprintf(&b, " %s", e.Move)
+	}
+	fmt.Fprintf(&b, ": expected %s, got %s", shortString(e.ExpectedScore), shortString(e.ActualScore))
+	if len(e.DivergedConstraints) > 0 {
+		fmt.Fprintf(&b, "; diverged constraints: %s", strings.Join(e.DivergedConstraints, ", "))
+	}
+	return b.String()
+}
+
+// ScoreAsserter 从头计算分数并与工作分数比较，用于断言环境模式
+type ScoreAsserter struct {
+	calculator        *ScoreCalulator
+	constraintManager api.IConstraintConfigure
+}
+
+func NewScoreAsserter(constraintManager api.IConstraintConfigure) *ScoreAsserter {
+	return &ScoreAsserter{
+		calculator:        NewScoreCalculator(constraintManager),
+		constraintManager: constraintManager,
+	}
+}
+
+// Snapshot 从头计算分数并记录各约束的匹配结果
+func (a *ScoreAsserter) Snapshot(solution api.ISolution) *ScoreSnapshot {
+	constraints := a.constraintManager.GetConstraints()
+	matches := make([]ConstraintMatch, 0, len(constraints))
+	for _, c := range constraints {
+		matchWeight := constraint.MatchWeightOf(c, solution)
+		matches = append(matches, ConstraintMatch{
+			ConstraintName: c.GetName(),
+			Matched:        matchWeight != 0,
+			MatchWeight:    matchWeight,
+		})
+	}
+	return &ScoreSnapshot{
+		Score:   a.calculator.Calculate(solution),
+		Matches: matches,
+	}
+}
+
+// AssertWorkingScore 比较工作分数（可能为增量计算）与从头计算的分数
+// before 为移动前的快照，用于找出受移动影响的约束，为 nil 时报告所有匹配的约束
+func (a *ScoreAsserter) AssertWorkingScore(before *ScoreSnapshot, workingScore api.IScore, solution api.ISolution, move api.IMove, stage string) error {
+	scratch := a.Snapshot(solution)
+	if workingScore != nil && workingScore.CompareTo(scratch.Score) == 0 {
+		return nil
+	}
+	var diverged []string
+	if before != nil {
+		diverged = divergedConstraints(before, scratch, "before move", stage)
+	} else {
+		for _, match := range scratch.Matches {
+			if match.Matched {
+				diverged = append(diverged, fmt.Sprintf("%s (matched from scratch)", match.ConstraintName))
+			}
+		}
+	}
+	return &ScoreCorruptionError{
+		Move:                describeMove(move),
+		Stage:               stage,
+		ExpectedScore:       scratch.Score,
+		ActualScore:         workingScore,
+		DivergedConstraints: diverged,
+	}
+}
+
+// AssertUndo 比较撤销移动后从头计算的结果与移动前的快照
+func (a *ScoreAsserter) AssertUndo(before *ScoreSnapshot, solution api.ISolution, move api.IMove) error {
+	after := a.Snapshot(solution)
+	diverged := divergedConstraints(before, after, "before move", "after undo")
+	if before.Score.CompareTo(after.Score) == 0 && len(diverged) == 0 {
+		return nil
+	}
+	return &ScoreCorruptionError{
+		Move:                describeMove(move),
+		Stage:               "after undo",
+		ExpectedScore:       before.Score,
+		ActualScore:         after.Score,
+		DivergedConstraints: diverged,
+	}
+}
+
+// divergedConstraints 找出两个快照之间匹配结果不同的约束
+func divergedConstraints(before, after *ScoreSnapshot, beforeStage, afterStage string) []string {
+	diverged := make([]string, 0)
+	for i, match := range after.Matches {
+		if i >= len(before.Matches) {
+			break
+		}
+		if before.Matches[i].MatchWeight != match.MatchWeight {
+			diverged = append(diverged, fmt.Sprintf("%s (match weight %d %s, %d %s)",
+				match.ConstraintName, before.Matches[i].MatchWeight, beforeStage, match.MatchWeight, afterStage))
+		}
+	}
+	return diverged
+}
+
+func describeMove(move api.IMove) string {
+	if move == nil {
+		return ""
+	}
+	if stringer, ok := move.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%T", move)
+}
+
+func shortString(score api.IScore) string {
+	if score == nil {
+		return "<nil>"
+	}
+	return score.ToShortString()
+}
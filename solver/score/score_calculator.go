@@ -1,7 +1,14 @@
 package score
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	hardsoft "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	"github.com/kruily/go-timefold-solver/solver/solution"
 )
 
 type ScoreCalulator struct {
@@ -13,15 +20,49 @@ func NewScoreCalculator(constraintManager api.IConstraintConfigure) *ScoreCalula
 	return &ScoreCalulator{constraintManager: constraintManager}
 }
 
-func (s *ScoreCalulator) Calculate(solution api.ISolution) api.IScore {
-	hardScore := 0
-	softScore := 0
-	for _, constraint := range s.constraintManager.GetConstraints() {
-		if constraint.Match(solution) {
-			score := constraint.GetScore().(*HardSoftScore)
-			hardScore += score.hardScore * constraint.GetWeight()
-			softScore += score.softScore * constraint.GetWeight()
+// Calculate 计算得分，约束的分数类型不一致时返回 nil，需要错误原因时使用 CalculateContext
+func (s *ScoreCalulator) Calculate(workingSolution api.ISolution) api.IScore {
+	score, _ := s.CalculateContext(context.Background(), workingSolution)
+	return score
+}
+
+// CalculateContext 计算得分，每个约束匹配前检查上下文是否已取消
+// 分数类型由约束的单位分数决定，约束的分数类型不一致时返回 *scoredef.ScoreTypeMismatchError
+func (s *ScoreCalulator) CalculateContext(ctx context.Context, workingSolution api.ISolution) (api.IScore, error) {
+	constraints := s.constraintManager.GetConstraints()
+	total, err := zeroScore(constraints)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range constraints {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if matchWeight := constraint.MatchWeightOf(c, workingSolution); matchWeight != 0 {
+			total = total.Add(c.GetScore().Multiply(float64(c.GetWeight() * matchWeight)))
+		}
+	}
+	// 初始化分数为未初始化变量数量的相反数
+	initScore := -solution.CountUninitializedVariables(workingSolution)
+	return total.WithInitScore(initScore), nil
+}
+
+// CheckConstraintScoreTypes 检查所有约束的单位分数类型一致
+func CheckConstraintScoreTypes(constraintManager api.IConstraintConfigure) error {
+	_, err := zeroScore(constraintManager.GetConstraints())
+	return err
+}
+
+// zeroScore 获取约束分数类型的零分，没有约束时为 HardSoftScore 的零分
+func zeroScore(constraints []api.IConstraint) (api.IScore, error) {
+	if len(constraints) == 0 {
+		return hardsoft.ZERO, nil
+	}
+	zero := constraints[0].GetScore().Zero()
+	for _, constraint := range constraints[1:] {
+		if err := scoredef.Check(zero, constraint.GetScore()); err != nil {
+			return nil, fmt.Errorf("constraint %q: %w", constraint.GetName(), err)
 		}
 	}
-	return NewHardSoftScore(hardScore, softScore, 0)
+	return zero, nil
 }
@@ -1,12 +1,19 @@
 package score
 
-import "github.com/kruily/go-timefold-solver/solver/api"
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
 
 type ScoreDirector struct {
 	calculator           *ScoreCalulator
 	increamentCalculator *IncrementalScoreCalculator
 	solution             api.ISolution
 	useIncreament        bool
+	// 分数计算次数，克隆的分数指导器共享同一计数
+	calculationCount *atomic.Int64
 }
 
 func NewScoreDirector(calculator *ScoreCalulator, constraintManager api.IConstraintConfigure) *ScoreDirector {
@@ -14,10 +21,12 @@ func NewScoreDirector(calculator *ScoreCalulator, constraintManager api.IConstra
 		calculator:           calculator,
 		increamentCalculator: NewIncrementalScoreCalculator(constraintManager),
 		useIncreament:        false,
+		calculationCount:     new(atomic.Int64),
 	}
 }
 
 func (s *ScoreDirector) Calculate(solution api.ISolution) api.IScore {
+	s.calculationCount.Add(1)
 	if s.useIncreament {
 		// 增量计算
 		s.increamentCalculator.solution = solution
@@ -27,6 +36,18 @@ func (s *ScoreDirector) Calculate(solution api.ISolution) api.IScore {
 	return s.calculator.Calculate(solution)
 }
 
+// CalculateContext 计算得分，上下文取消时返回上下文的错误
+func (s *ScoreDirector) CalculateContext(ctx context.Context, solution api.ISolution) (api.IScore, error) {
+	if s.useIncreament {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return s.Calculate(solution), nil
+	}
+	s.calculationCount.Add(1)
+	return s.calculator.CalculateContext(ctx, solution)
+}
+
 func (s *ScoreDirector) BeforeVariableChanged(planningVariable api.IPlanningVariable) {
 	if s.useIncreament {
 		s.increamentCalculator.BeforeVariableChange(planningVariable)
@@ -47,6 +68,42 @@ func (s *ScoreDirector) SetWorkingSolution(solution api.ISolution) {
 	s.solution = solution
 }
 
+// ResetWorkingSolution 设置工作解决方案并丢弃增量状态，用于问题变更后
+func (s *ScoreDirector) ResetWorkingSolution(solution api.ISolution) {
+	s.solution = solution
+	if s.useIncreament {
+		s.increamentCalculator.Reset(solution, s.calculator.Calculate(solution))
+	}
+}
+
+// GetConstraintManager 获取约束配置，用于从头计算分数
+func (s *ScoreDirector) GetConstraintManager() api.IConstraintConfigure {
+	return s.calculator.constraintManager
+}
+
+// SetConstraintManager 替换约束配置，用于应用约束权重覆盖
+func (s *ScoreDirector) SetConstraintManager(constraintManager api.IConstraintConfigure) {
+	s.calculator = NewScoreCalculator(constraintManager)
+	s.increamentCalculator = NewIncrementalScoreCalculator(constraintManager)
+	if s.solution != nil && s.useIncreament {
+		s.increamentCalculator.Reset(s.solution, s.calculator.Calculate(s.solution))
+	}
+}
+
+// CloneScoreDirector 创建使用相同约束的新分数指导器，不共享工作解决方案与增量缓存
+func (s *ScoreDirector) CloneScoreDirector() api.IScoreDirector {
+	constraintManager := s.calculator.constraintManager
+	clone := NewScoreDirector(NewScoreCalculator(constraintManager), constraintManager)
+	clone.useIncreament = s.useIncreament
+	clone.calculationCount = s.calculationCount
+	return clone
+}
+
+// GetCalculationCount 获取分数计算次数，包括克隆的分数指导器的计算
+func (s *ScoreDirector) GetCalculationCount() int64 {
+	return s.calculationCount.Load()
+}
+
 func (s *ScoreDirector) SetUseIncreament(useIncreament bool) {
 	s.useIncreament = useIncreament
 }
@@ -0,0 +1,143 @@
+package score
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+)
+
+// ScoreExplanation 分数明细，说明每个约束对分数的影响
+type ScoreExplanation struct {
+	Score       api.IScore
+	Constraints []ConstraintExplanation
+}
+
+// ConstraintExplanation 单个约束对分数的影响
+type ConstraintExplanation struct {
+	ConstraintName    string
+	ConstraintPackage string
+	Weight            int
+	Matched           bool
+	// 匹配权重之和
+	MatchWeight int
+	// 每次匹配的权重与说明对象
+	Matches []api.ConstraintMatch
+	// 约束的分数（单位分数乘以权重再乘以匹配权重），未匹配时为零分
+	Score api.IScore
+}
+
+// ExplainScore 从头计算分数并说明每个约束的影响，约束的分数类型不一致时返回 *scoredef.ScoreTypeMismatchError
+func ExplainScore(constraintManager api.IConstraintConfigure, solution api.ISolution) (*ScoreExplanation, error) {
+	constraints := constraintManager.GetConstraints()
+	total, err := NewScoreCalculator(constraintManager).CalculateContext(context.Background(), solution)
+	if err != nil {
+		return nil, err
+	}
+	explanation := &ScoreExplanation{
+		Score:       total,
+		Constraints: make([]ConstraintExplanation, 0, len(constraints)),
+	}
+	for _, c := range constraints {
+		unitScore := c.GetScore()
+		constraintScore := unitScore.Zero()
+		matches := constraint.MatchesOf(c, solution)
+		matchWeight := 0
+		for _, match := range matches {
+			matchWeight += match.Weight
+		}
+		if matchWeight != 0 {
+			constraintScore = unitScore.Multiply(float64(c.GetWeight() * matchWeight))
+		}
+		explanation.Constraints = append(explanation.Constraints, ConstraintExplanation{
+			ConstraintName:    c.GetName(),
+			ConstraintPackage: constraint.PackageOf(c),
+			Weight:            c.GetWeight(),
+			Matched:           matchWeight != 0,
+			MatchWeight:       matchWeight,
+			Matches:           matches,
+			Score:             constraintScore,
+		})
+	}
+	return explanation, nil
+}
+
+// Summary 输出可读的分数明细，每行一个约束
+func (e *ScoreExplanation) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Score: %s\n", shortString(e.Score))
+	for _, c := range e.Constraints {
+		status := "not matched"
+		if c.Matched {
+			status = fmt.Sprintf("matched %d time(s), match weight %d", len(c.Matches), c.MatchWeight)
+		}
+		name := c.ConstraintName
+		if c.ConstraintPackage != "" {
+			name = c.ConstraintPackage + "/" + name
+		}
+		fmt.Fprintf(&b, "  %s (weight %d): %s, %s\n", name, c.Weight, status, shortString(c.Score))
+		for _, match := range c.Matches {
+			if match.Justification != nil {
+				fmt.Fprintf(&b, "    weight %d: %+v\n", match.Weight, match.Justification)
+			}
+		}
+	}
+	return b.String()
+}
+
+type explanationJSON struct {
+	Score       string           `json:"score"`
+	Constraints []constraintJSON `json:"constraints"`
+}
+
+type constraintJSON struct {
+	Name        string      `json:"name"`
+	Package     string      `json:"package,omitempty"`
+	Weight      int         `json:"weight"`
+	Matched     bool        `json:"matched"`
+	MatchWeight int         `json:"match_weight"`
+	Score       string      `json:"score"`
+	Matches     []matchJSON `json:"matches,omitempty"`
+}
+
+type matchJSON struct {
+	Weight        int         `json:"weight"`
+	Justification interface{} `json:"justification,omitempty"`
+}
+
+// WriteJSON 以 JSON 输出分数明细，分数输出为短字符串，说明对象按其 JSON 编码输出
+func (e *ScoreExplanation) WriteJSON(w io.Writer) error {
+	out := explanationJSON{
+		Score:       shortString(e.Score),
+		Constraints: make([]constraintJSON, 0, len(e.Constraints)),
+	}
+	for _, c := range e.Constraints {
+		out.Constraints = append(out.Constraints, constraintJSON{
+			Name:        c.ConstraintName,
+			Package:     c.ConstraintPackage,
+			Weight:      c.Weight,
+			Matched:     c.Matched,
+			MatchWeight: c.MatchWeight,
+			Score:       shortString(c.Score),
+			Matches:     matchesJSON(c.Matches),
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("write score explanation: %w", err)
+	}
+	return nil
+}
+
+func matchesJSON(matches []api.ConstraintMatch) []matchJSON {
+	out := make([]matchJSON, 0, len(matches))
+	for _, match := range matches {
+		out = append(out, matchJSON{Weight: match.Weight, Justification: match.Justification})
+	}
+	return out
+}
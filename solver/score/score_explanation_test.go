@@ -0,0 +1,60 @@
+package score
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+)
+
+type emptySolution struct {
+	score api.IScore
+}
+
+func (s *emptySolution) GetScore() api.IScore                               { return s.score }
+func (s *emptySolution) SetScore(score api.IScore)                          { s.score = score }
+func (s *emptySolution) GetPlanningEntities() []api.IPlanningEntity         { return nil }
+func (s *emptySolution) SetPlanningEntities(entities []api.IPlanningEntity) {}
+func (s *emptySolution) GetProblemFacts() []interface{}                     { return nil }
+func (s *emptySolution) SetProblemFacts(facts []interface{})                {}
+
+func alwaysMatch(api.ISolution) bool { return true }
+func neverMatch(api.ISolution) bool  { return false }
+
+func TestExplainScore(t *testing.T) {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(constraint.WithName("hard"), constraint.WithWeight(-2), constraint.WithType(constraint.HARD), constraint.WithMatchFunc(alwaysMatch)))
+	cm.AddConstraint(constraint.NewConstraint(constraint.WithName("soft"), constraint.WithWeight(-1), constraint.WithType(constraint.SOFT), constraint.WithMatchFunc(neverMatch)))
+
+	explanation, err := ExplainScore(cm, &emptySolution{})
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if got, want := explanation.Score.ToShortString(), "HardSoftScore[initScore=0, hardScore=-2, softScore=0]"; got != want {
+		t.Errorf("got score %s, want %s", got, want)
+	}
+	if len(explanation.Constraints) != 2 || !explanation.Constraints[0].Matched || explanation.Constraints[1].Matched {
+		t.Errorf("got constraints %+v", explanation.Constraints)
+	}
+}
+
+func TestExplainScoreTypeMismatch(t *testing.T) {
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(constraint.WithName("hard"), constraint.WithWeight(-1), constraint.WithType(constraint.HARD), constraint.WithMatchFunc(alwaysMatch)))
+	cm.AddConstraint(constraint.NewConstraint(constraint.WithName("simple"), constraint.WithWeight(-1), constraint.WithScore(simple.ONE), constraint.WithMatchFunc(alwaysMatch)))
+
+	explanation, err := ExplainScore(cm, &emptySolution{})
+	var mismatch *scoredef.ScoreTypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("got error %v, want *ScoreTypeMismatchError", err)
+	}
+	if explanation != nil {
+		t.Errorf("got explanation %+v, want nil", explanation)
+	}
+	if got := NewScoreCalculator(cm).Calculate(&emptySolution{}); got != nil {
+		t.Errorf("Calculate got %v, want nil", got)
+	}
+}
@@ -0,0 +1,208 @@
+package scoredef
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// ScoreTypeMismatchError 两个分数的类型不一致，无法运算或比较
+type ScoreTypeMismatchError struct {
+	// 运算名称，如 "add"、"compare"
+	Operation string
+	Expected  string
+	Actual    string
+}
+
+func (e *ScoreTypeMismatchError) Error() string {
+	return fmt.Sprintf("score type mismatch in %s: expected %s, got %s", e.Operation, e.Expected, e.Actual)
+}
+
+// Check 检查两个分数的类型一致，任一分数为 nil 时不检查
+func Check(a, b api.IScore) error {
+	return check("check", a, b)
+}
+
+func check(operation string, a, b api.IScore) error {
+	if a == nil || b == nil {
+		return nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return &ScoreTypeMismatchError{Operation: operation, Expected: fmt.Sprintf("%T", a), Actual: fmt.Sprintf("%T", b)}
+	}
+	return nil
+}
+
+// checkOperands 检查运算的两个分数都不为 nil 且类型一致
+func checkOperands(operation string, a, b api.IScore) error {
+	if a == nil || b == nil {
+		return fmt.Errorf("%s: score is nil", operation)
+	}
+	return check(operation, a, b)
+}
+
+// Add 相加两个分数，类型不一致时返回 *ScoreTypeMismatchError 而不是 panic
+func Add(a, b api.IScore) (api.IScore, error) {
+	if err := checkOperands("add", a, b); err != nil {
+		return nil, err
+	}
+	return a.Add(b), nil
+}
+
+// Subtract 相减两个分数，类型不一致时返回 *ScoreTypeMismatchError 而不是 panic
+func Subtract(a, b api.IScore) (api.IScore, error) {
+	if err := checkOperands("subtract", a, b); err != nil {
+		return nil, err
+	}
+	return a.Subtract(b), nil
+}
+
+// Compare 比较两个分数，类型不一致时返回 *ScoreTypeMismatchError 而不是 panic
+func Compare(a, b api.IScore) (int, error) {
+	if err := checkOperands("compare", a, b); err != nil {
+		return 0, err
+	}
+	return a.CompareTo(b), nil
+}
+
+// Difference 获取 a 与 b 第一个不同级别（含初始化分数）的差，相同时为 0
+// CompareTo 只表示先后，需要差值大小时（如模拟退火的接受概率）使用 Difference，以浮点数计算避免溢出
+func Difference(a, b api.IScore) float64 {
+	x, y := a.ToLevelDoubles(), b.ToLevelDoubles()
+	for i := range min(len(x), len(y)) {
+		if x[i] != y[i] {
+			return x[i] - y[i]
+		}
+	}
+	return 0
+}
+
+// Definition 分数类型的定义，S 为具体的分数类型
+// 按 ToLevelNumbers 的级别（第一个为初始化分数）实现分数之间的运算，分数类型不需要断言另一个分数的具体类型
+type Definition[S api.IScore] struct {
+	name       string
+	levelCount int
+	newScore   func(initScore int, levels []int) S
+}
+
+// NewDefinition 创建分数类型的定义，levelCount 为不含初始化分数的级别数，newScore 以初始化分数与级别创建分数
+func NewDefinition[S api.IScore](name string, levelCount int, newScore func(initScore int, levels []int) S) *Definition[S] {
+	return &Definition[S]{name: name, levelCount: levelCount, newScore: newScore}
+}
+
+func (d *Definition[S]) GetName() string {
+	return d.name
+}
+
+func (d *Definition[S]) GetLevelCount() int {
+	return d.levelCount
+}
+
+// Of 将分数转换为具体类型，类型不一致时返回 *ScoreTypeMismatchError
+func (d *Definition[S]) Of(score api.IScore) (S, error) {
+	s, ok := score.(S)
+	if !ok {
+		var zero S
+		return zero, &ScoreTypeMismatchError{Operation: "convert", Expected: d.name, Actual: fmt.Sprintf("%T", score)}
+	}
+	return s, nil
+}
+
+// Add 按级别相加
+func (d *Definition[S]) Add(a S, b api.IScore) (S, error) {
+	return d.combine("add", a, b, func(x, y int) int { return x + y })
+}
+
+// Subtract 按级别相减
+func (d *Definition[S]) Subtract(a S, b api.IScore) (S, error) {
+	return d.combine("subtract", a, b, func(x, y int) int { return x - y })
+}
+
+// Compare 先比较初始化分数，再按级别从高到低比较，a 较差、相同、较好时分别返回 -1、0、1
+func (d *Definition[S]) Compare(a S, b api.IScore) (int, error) {
+	x, y, err := d.levels("compare", a, b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range x {
+		if c := cmp.Compare(x[i], y[i]); c != 0 {
+			return c, nil
+		}
+	}
+	return 0, nil
+}
+
+// Multiply 按级别乘以 multiplicand 并向下取整
+func (d *Definition[S]) Multiply(a S, multiplicand float64) S {
+	return d.scale(a, func(x float64) float64 { return x * multiplicand })
+}
+
+// Divide 按级别除以 divisor 并向下取整
+func (d *Definition[S]) Divide(a S, divisor float64) S {
+	return d.scale(a, func(x float64) float64 { return x / divisor })
+}
+
+// Power 按级别求 exponent 次方并向下取整
+func (d *Definition[S]) Power(a S, exponent float64) S {
+	return d.scale(a, func(x float64) float64 { return math.Pow(x, exponent) })
+}
+
+// MustAdd 与 Add 相同，类型不一致时以 *ScoreTypeMismatchError panic，用于实现 api.IScore
+func (d *Definition[S]) MustAdd(a S, b api.IScore) S {
+	return must(d.Add(a, b))
+}
+
+// MustSubtract 与 Subtract 相同，类型不一致时以 *ScoreTypeMismatchError panic，用于实现 api.IScore
+func (d *Definition[S]) MustSubtract(a S, b api.IScore) S {
+	return must(d.Subtract(a, b))
+}
+
+// MustCompare 与 Compare 相同，类型不一致时以 *ScoreTypeMismatchError panic，用于实现 api.IScore
+func (d *Definition[S]) MustCompare(a S, b api.IScore) int {
+	return must(d.Compare(a, b))
+}
+
+func (d *Definition[S]) combine(operation string, a S, b api.IScore, op func(x, y int) int) (S, error) {
+	x, y, err := d.levels(operation, a, b)
+	if err != nil {
+		var zero S
+		return zero, err
+	}
+	levels := make([]int, len(x))
+	for i := range x {
+		levels[i] = op(x[i], y[i])
+	}
+	return d.newScore(levels[0], levels[1:]), nil
+}
+
+// scale 对每个级别（含初始化分数）做浮点运算后向下取整，乘数不必为整数，如乘以 0.5
+func (d *Definition[S]) scale(a S, op func(x float64) float64) S {
+	x := a.ToLevelNumbers()
+	levels := make([]int, len(x))
+	for i := range x {
+		levels[i] = int(math.Floor(op(float64(x[i]))))
+	}
+	return d.newScore(levels[0], levels[1:])
+}
+
+// levels 获取两个分数的级别（含初始化分数），另一个分数的类型必须为 S
+func (d *Definition[S]) levels(operation string, a S, b api.IScore) ([]int, []int, error) {
+	if _, ok := b.(S); !ok {
+		return nil, nil, &ScoreTypeMismatchError{Operation: operation, Expected: d.name, Actual: fmt.Sprintf("%T", b)}
+	}
+	x, y := a.ToLevelNumbers(), b.ToLevelNumbers()
+	if len(x) != d.levelCount+1 || len(y) != d.levelCount+1 {
+		return nil, nil, fmt.Errorf("%s: %s expects %d levels plus the init score, got %d and %d", operation, d.name, d.levelCount, len(x)-1, len(y)-1)
+	}
+	return x, y, nil
+}
+
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package scoredef_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	score "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+)
+
+func TestCompareReturnsSign(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *score.HardSoftScore
+		want int
+	}{
+		{"equal", score.NewHardSoftScore(0, -1, -2), score.NewHardSoftScore(0, -1, -2), 0},
+		{"better soft", score.NewHardSoftScore(0, -1, 5), score.NewHardSoftScore(0, -1, -2), 1},
+		{"worse hard", score.NewHardSoftScore(0, -3, 100), score.NewHardSoftScore(0, -1, -2), -1},
+		{"init first", score.NewHardSoftScore(-1, 0, 0), score.NewHardSoftScore(0, -100, -100), -1},
+		// 直接相减会溢出并得到相反的符号
+		{"max against min", score.NewHardSoftScore(0, math.MaxInt, 0), score.NewHardSoftScore(0, math.MinInt, 0), 1},
+		{"min against max", score.NewHardSoftScore(0, 0, math.MinInt), score.NewHardSoftScore(0, 0, math.MaxInt), -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := score.DEFINITION.Compare(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a.ToShortString(), tt.b.ToShortString(), got, tt.want)
+			}
+			if got := tt.a.CompareTo(tt.b); got != tt.want {
+				t.Errorf("CompareTo = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareTypeMismatch(t *testing.T) {
+	_, err := score.DEFINITION.Compare(score.ZERO, simple.NewSimpleScore(0, 0))
+	var mismatch *scoredef.ScoreTypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("got error %v, want *ScoreTypeMismatchError", err)
+	}
+	if mismatch.Operation != "compare" {
+		t.Errorf("got operation %q, want compare", mismatch.Operation)
+	}
+}
+
+func TestScaleFloorsLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *score.HardSoftScore
+		want *score.HardSoftScore
+	}{
+		{"multiply by fraction", score.NewHardSoftScore(0, 3, -3).Multiply(0.5).(*score.HardSoftScore), score.NewHardSoftScore(0, 1, -2)},
+		{"multiply by integer", score.NewHardSoftScore(-1, 2, -3).Multiply(2).(*score.HardSoftScore), score.NewHardSoftScore(-2, 4, -6)},
+		{"divide", score.NewHardSoftScore(0, 7, -7).Divide(2).(*score.HardSoftScore), score.NewHardSoftScore(0, 3, -4)},
+		{"power", score.NewHardSoftScore(0, 3, -2).Power(2).(*score.HardSoftScore), score.NewHardSoftScore(0, 9, 4)},
+		{"square root", score.NewHardSoftScore(0, 9, 2).Power(0.5).(*score.HardSoftScore), score.NewHardSoftScore(0, 3, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.CompareTo(tt.want) != 0 {
+				t.Errorf("got %s, want %s", tt.got.ToShortString(), tt.want.ToShortString())
+			}
+		})
+	}
+
+	if got := simple.NewSimpleScore(0, -3).Multiply(0.5); got.CompareTo(simple.NewSimpleScore(0, -2)) != 0 {
+		t.Errorf("simple score: got %s, want -2", got.ToShortString())
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b *score.HardSoftScore
+		want float64
+	}{
+		{"equal", score.NewHardSoftScore(0, -1, -2), score.NewHardSoftScore(0, -1, -2), 0},
+		{"soft level", score.NewHardSoftScore(0, -1, 3), score.NewHardSoftScore(0, -1, -2), 5},
+		{"hard level first", score.NewHardSoftScore(0, -3, 100), score.NewHardSoftScore(0, -1, -2), -2},
+		{"no overflow", score.NewHardSoftScore(0, math.MaxInt, 0), score.NewHardSoftScore(0, math.MinInt, 0), 2 * float64(math.MaxInt)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scoredef.Difference(tt.a, tt.b); got != tt.want {
+				t.Errorf("Difference(%s, %s) = %v, want %v", tt.a.ToShortString(), tt.b.ToShortString(), got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
 )
 
 type SimpleScore struct {
@@ -20,6 +21,11 @@ var (
 	ZERO      = NewSimpleScore(0, 0)
 	ONE       = NewSimpleScore(0, 1)
 	MINUS_ONE = NewSimpleScore(0, -1)
+
+	// DEFINITION 按单个级别运算，与其他分数类型运算时以 *scoredef.ScoreTypeMismatchError panic
+	DEFINITION = scoredef.NewDefinition("SimpleScore", 1, func(initScore int, levels []int) *SimpleScore {
+		return ofUninitialized(initScore, levels[0])
+	})
 )
 
 // 解析分数
@@ -37,7 +43,7 @@ func of(score int) *SimpleScore {
 	case -1:
 		return MINUS_ONE
 	default:
-		return NewSimpleScore(score, 0)
+		return NewSimpleScore(0, score)
 	}
 }
 
@@ -59,55 +65,35 @@ func (s *SimpleScore) Score() int {
 }
 
 func (s *SimpleScore) IsFeasible() bool {
-	return s.score >= 0
+	return s.initScore >= 0 && s.score >= 0
 }
 
 func (s *SimpleScore) CompareTo(other api.IScore) int {
-	otherScore := other.(*SimpleScore)
-	if s.initScore != otherScore.initScore {
-		return s.initScore - otherScore.initScore
-	}
-	return s.score - otherScore.score
+	return DEFINITION.MustCompare(s, other)
 }
 
 func (s *SimpleScore) WithInitScore(score int) api.IScore {
-	s.initScore = score
-	return s
+	return ofUninitialized(score, s.score)
 }
 
 func (s *SimpleScore) Add(score api.IScore) api.IScore {
-	return ofUninitialized(
-		s.initScore+score.InitScore(),
-		s.score+score.(*SimpleScore).Score(),
-	)
+	return DEFINITION.MustAdd(s, score)
 }
 
 func (s *SimpleScore) Subtract(score api.IScore) api.IScore {
-	return ofUninitialized(
-		s.initScore-score.InitScore(),
-		s.score-score.(*SimpleScore).Score(),
-	)
+	return DEFINITION.MustSubtract(s, score)
 }
 
 func (s *SimpleScore) Multiply(multiplicand float64) api.IScore {
-	return ofUninitialized(
-		s.initScore*int(multiplicand),
-		s.score*int(multiplicand),
-	)
+	return DEFINITION.Multiply(s, multiplicand)
 }
 
 func (s *SimpleScore) Divide(divisor float64) api.IScore {
-	return ofUninitialized(
-		int(s.initScore/int(divisor)),
-		int(s.score/int(divisor)),
-	)
+	return DEFINITION.Divide(s, divisor)
 }
 
 func (s *SimpleScore) Power(exponent float64) api.IScore {
-	return ofUninitialized(
-		int(math.Pow(float64(s.initScore), exponent)),
-		int(math.Pow(float64(s.score), exponent)),
-	)
+	return DEFINITION.Power(s, exponent)
 }
 
 func (s *SimpleScore) Negate() api.IScore {
@@ -133,7 +119,7 @@ func (s *SimpleScore) IsZero() bool {
 }
 
 func (s *SimpleScore) IsSolutionInitailized() bool {
-	return s.initScore != 0
+	return s.initScore >= 0
 }
 
 func (s *SimpleScore) ToLevelNumbers() []int {
@@ -0,0 +1,27 @@
+package solution
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// IsNullable 规划变量是否允许保持未赋值
+func IsNullable(variable api.IPlanningVariable) bool {
+	nullable, ok := variable.(api.NullablePlanningVariable)
+	return ok && nullable.IsNullable()
+}
+
+// IsInitialized 规划变量是否已初始化，可为空的变量始终视为已初始化
+func IsInitialized(variable api.IPlanningVariable) bool {
+	return variable.GetValue() != nil || IsNullable(variable)
+}
+
+// CountUninitializedVariables 统计解决方案中未初始化的规划变量数量
+func CountUninitializedVariables(solution api.ISolution) int {
+	count := 0
+	for _, entity := range GetPlanningEntities(solution) {
+		for _, variable := range entity.GetPlanningVariables() {
+			if !IsInitialized(variable) {
+				count++
+			}
+		}
+	}
+	return count
+}
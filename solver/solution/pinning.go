@@ -0,0 +1,34 @@
+package solution
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// 提供固定实体的解决方案，固定的实体参与分数计算但不会被移动
+type pinnedEntityProvider interface {
+	IsPinned(entity api.IPlanningEntity) bool
+}
+
+// IsPinned 规划实体是否被固定
+// 实体的规划过滤器接受该实体，或解决方案（如分区的边界）固定了该实体时视为固定
+func IsPinned(solution api.ISolution, entity api.IPlanningEntity) bool {
+	if provider, ok := solution.(pinnedEntityProvider); ok && provider.IsPinned(entity) {
+		return true
+	}
+	if annotated, ok := entity.(api.PlanningEntity); ok {
+		if filter := annotated.PinningFilter(); filter != nil && filter.Accept(solution, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMovablePlanningEntities 获取解决方案中未被固定的规划实体
+func GetMovablePlanningEntities(solution api.ISolution) []api.IPlanningEntity {
+	entities := GetPlanningEntities(solution)
+	movable := make([]api.IPlanningEntity, 0, len(entities))
+	for _, entity := range entities {
+		if !IsPinned(solution, entity) {
+			movable = append(movable, entity)
+		}
+	}
+	return movable
+}
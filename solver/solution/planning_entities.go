@@ -0,0 +1,18 @@
+package solution
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// GetPlanningEntities 获取解决方案中的所有规划实体
+// 优先使用 ISolution.GetPlanningEntities，兼容把实体放在问题事实中的用法
+func GetPlanningEntities(solution api.ISolution) []api.IPlanningEntity {
+	if entities := solution.GetPlanningEntities(); len(entities) > 0 {
+		return entities
+	}
+	var entities []api.IPlanningEntity
+	for _, fact := range solution.GetProblemFacts() {
+		if entity, ok := fact.(api.IPlanningEntity); ok {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
@@ -0,0 +1,9 @@
+package solution
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// SolutionPartitioner 将解决方案拆分为多个分区，由分区搜索并发求解
+// 分区通过 NewPartition 创建，一个分区中可移动的实体不能出现在其他分区中（包括边界）
+type SolutionPartitioner interface {
+	SplitWorkingSolution(workingSolution api.ISolution) []*SubSolution
+}
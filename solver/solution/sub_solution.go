@@ -5,6 +5,11 @@ import "github.com/kruily/go-timefold-solver/solver/api"
 type SubSolution struct {
 	originalSolution api.ISolution
 	dirtyEntities    map[api.IPlanningEntity]struct{}
+	// 分区的边界实体，参与分数计算但被固定
+	pinnedEntities map[api.IPlanningEntity]struct{}
+	// 分区拥有独立的分数，以便多个分区并发求解
+	partition bool
+	score     api.IScore
 }
 
 func NewSubSolution(originalSolution api.ISolution, dirtyEntities map[api.IPlanningEntity]struct{}) *SubSolution {
@@ -14,6 +19,24 @@ func NewSubSolution(originalSolution api.ISolution, dirtyEntities map[api.IPlann
 	}
 }
 
+// NewPartition 创建分区，entities 为分区内可移动的实体，boundary 为固定的边界实体
+func NewPartition(originalSolution api.ISolution, entities []api.IPlanningEntity, boundary []api.IPlanningEntity) *SubSolution {
+	sub := &SubSolution{
+		originalSolution: originalSolution,
+		dirtyEntities:    make(map[api.IPlanningEntity]struct{}, len(entities)+len(boundary)),
+		pinnedEntities:   make(map[api.IPlanningEntity]struct{}, len(boundary)),
+		partition:        true,
+	}
+	for _, entity := range entities {
+		sub.dirtyEntities[entity] = struct{}{}
+	}
+	for _, entity := range boundary {
+		sub.dirtyEntities[entity] = struct{}{}
+		sub.pinnedEntities[entity] = struct{}{}
+	}
+	return sub
+}
+
 func (s *SubSolution) GetOriginalSolution() api.ISolution {
 	return s.originalSolution
 }
@@ -22,14 +45,54 @@ func (s *SubSolution) GetDirtyEntities() map[api.IPlanningEntity]struct{} {
 	return s.dirtyEntities
 }
 
+// IsPartition 是否为分区
+func (s *SubSolution) IsPartition() bool {
+	return s.partition
+}
+
+// IsPinned 实体是否为分区的边界实体
+func (s *SubSolution) IsPinned(entity api.IPlanningEntity) bool {
+	_, ok := s.pinnedEntities[entity]
+	return ok
+}
+
 func (s *SubSolution) GetScore() api.IScore {
+	if s.partition {
+		return s.score
+	}
 	return s.originalSolution.GetScore()
 }
 
 func (s *SubSolution) SetScore(score api.IScore) {
+	if s.partition {
+		s.score = score
+		return
+	}
 	s.originalSolution.SetScore(score)
 }
 
 func (s *SubSolution) GetProblemFacts() []interface{} {
 	return s.originalSolution.GetProblemFacts()
 }
+
+func (s *SubSolution) SetProblemFacts(facts []interface{}) {
+	s.originalSolution.SetProblemFacts(facts)
+}
+
+// GetPlanningEntities 只返回脏实体，顺序与原解决方案一致
+func (s *SubSolution) GetPlanningEntities() []api.IPlanningEntity {
+	entities := make([]api.IPlanningEntity, 0, len(s.dirtyEntities))
+	for _, entity := range GetPlanningEntities(s.originalSolution) {
+		if _, ok := s.dirtyEntities[entity]; ok {
+			entities = append(entities, entity)
+		}
+	}
+	return entities
+}
+
+func (s *SubSolution) SetPlanningEntities(entities []api.IPlanningEntity) {
+	s.dirtyEntities = make(map[api.IPlanningEntity]struct{}, len(entities))
+	for _, entity := range entities {
+		s.dirtyEntities[entity] = struct{}{}
+	}
+}
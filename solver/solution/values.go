@@ -0,0 +1,12 @@
+package solution
+
+import "reflect"
+
+// IsSameValue 规划变量的两个值是否相同，值相同时不需要通知分数指导器
+// 不可比较的值（如切片、映射）总视为不同，避免 == 比较时 panic
+func IsSameValue(a, b interface{}) bool {
+	if t := reflect.TypeOf(a); t != nil && !t.Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,42 @@
+package solution
+
+import "github.com/kruily/go-timefold-solver/solver/api"
+
+// WorkingObjectLookup 按位置将源解决方案的实体与变量映射到目标解决方案
+// 要求目标解决方案是源解决方案的克隆，实体与变量的顺序一致
+type WorkingObjectLookup struct {
+	entities  map[api.IPlanningEntity]api.IPlanningEntity
+	variables map[api.IPlanningVariable]api.IPlanningVariable
+}
+
+func NewWorkingObjectLookup(source, destination api.ISolution) *WorkingObjectLookup {
+	lookup := &WorkingObjectLookup{
+		entities:  make(map[api.IPlanningEntity]api.IPlanningEntity),
+		variables: make(map[api.IPlanningVariable]api.IPlanningVariable),
+	}
+	sourceEntities := GetPlanningEntities(source)
+	destinationEntities := GetPlanningEntities(destination)
+	for i, entity := range sourceEntities {
+		if i >= len(destinationEntities) {
+			break
+		}
+		lookup.entities[entity] = destinationEntities[i]
+		sourceVariables := entity.GetPlanningVariables()
+		destinationVariables := destinationEntities[i].GetPlanningVariables()
+		for j, variable := range sourceVariables {
+			if j >= len(destinationVariables) {
+				break
+			}
+			lookup.variables[variable] = destinationVariables[j]
+		}
+	}
+	return lookup
+}
+
+func (l *WorkingObjectLookup) LookUpEntity(entity api.IPlanningEntity) api.IPlanningEntity {
+	return l.entities[entity]
+}
+
+func (l *WorkingObjectLookup) LookUpVariable(variable api.IPlanningVariable) api.IPlanningVariable {
+	return l.variables[variable]
+}
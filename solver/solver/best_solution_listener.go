@@ -0,0 +1,30 @@
+package solver
+
+import (
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// BestSolutionChangedListener 最佳解决方案改进时的回调，在求解协程中调用
+// bestSolution 为工作解决方案，回调返回后可能继续被修改，需要保留时应当在回调中复制
+type BestSolutionChangedListener func(bestSolution api.ISolution, bestScore api.IScore)
+
+// AddBestSolutionChangedListener 添加最佳解决方案改进时的回调，求解开始时的初始分数也会触发回调
+func (s *DefaultSolver) AddBestSolutionChangedListener(listener BestSolutionChangedListener) {
+	s.bestSolutionListeners = append(s.bestSolutionListeners, listener)
+}
+
+func (s *DefaultSolver) fireBestSolutionChanged() {
+	for _, listener := range s.bestSolutionListeners {
+		listener(s.bestSolution, s.bestScore)
+	}
+}
+
+// GetStepCount 获取最近一次求解的步数
+func (s *DefaultSolver) GetStepCount() int {
+	return s.scope.GetStepCount()
+}
+
+// GetMoveCount 获取最近一次求解评估的移动数
+func (s *DefaultSolver) GetMoveCount() int64 {
+	return s.scope.GetMoveCount()
+}
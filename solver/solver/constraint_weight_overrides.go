@@ -0,0 +1,53 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/move"
+	"github.com/kruily/go-timefold-solver/solver/score"
+)
+
+// SetConstraintWeightOverrides 设置之后每次求解使用的约束权重覆盖，优先于问题携带的覆盖，nil 表示不覆盖
+func (s *DefaultSolver) SetConstraintWeightOverrides(overrides *constraint.ConstraintWeightOverrides) {
+	s.constraintWeightOverrides = overrides
+}
+
+// applyConstraintWeightOverrides 在求解开始前将约束权重覆盖应用到分数指导器
+// 覆盖总是基于分数指导器最初的约束配置，多次求解不会叠加
+func (s *DefaultSolver) applyConstraintWeightOverrides(problem api.ISolution) error {
+	overrides := s.constraintWeightOverrides
+	if overrides == nil {
+		if provider, ok := problem.(constraint.ConstraintWeightOverridesProvider); ok {
+			overrides = provider.GetConstraintWeightOverrides()
+		}
+	}
+	director, ok := s.scoreDirector.(api.IConstraintConfigurableScoreDirector)
+	if !ok {
+		if overrides != nil {
+			return fmt.Errorf("constraint weight overrides require a configurable score director, got %T", s.scoreDirector)
+		}
+		return nil
+	}
+	if s.baseConstraintManager == nil {
+		s.baseConstraintManager = director.GetConstraintManager()
+	}
+
+	constraintManager := s.baseConstraintManager
+	if overrides != nil {
+		if err := overrides.Validate(constraintManager); err != nil {
+			return err
+		}
+		constraintManager = overrides.Apply(constraintManager)
+	}
+	director.SetConstraintManager(constraintManager)
+	if s.asserter != nil {
+		s.asserter = score.NewScoreAsserter(constraintManager)
+		if selector, ok := s.moveSelector.(*move.DefaultMoveSelector); ok && s.config.EnvironmentMode == config.EnvironmentModeFullAssert {
+			selector.SetScoreAsserter(s.asserter)
+		}
+	}
+	return nil
+}
@@ -2,16 +2,24 @@ package solver
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
-	"math/rand/v2"
-	"sort"
+	"math/rand"
 	"sync"
 	"time"
 
 	"github.com/kruily/go-timefold-solver/solver/api"
 	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/heuristic"
 	"github.com/kruily/go-timefold-solver/solver/move"
+	"github.com/kruily/go-timefold-solver/solver/nearby"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	"github.com/kruily/go-timefold-solver/solver/solution"
 	"github.com/kruily/go-timefold-solver/solver/tabu"
+	"github.com/kruily/go-timefold-solver/solver/termination"
 )
 
 type DefaultSolver struct {
@@ -20,21 +28,51 @@ type DefaultSolver struct {
 	moveSelector  move.MoveSelector
 	tabuAcceptor  *tabu.TabuSearchAcceptor
 	currentMove   api.IMove
-
-	terminated   bool
-	terminateMu  sync.Mutex
-	bestSolution api.ISolution
-	bestScore    api.IScore
+	termination   termination.Termination
+	scope         *termination.Scope
+	// 求解器范围的随机源，由 RandomSeed 派生并传递给所有选择器与接受器
+	random *rand.Rand
+	// 断言环境模式下的分数断言器，其他模式为 nil
+	asserter *score.ScoreAsserter
+	// 断言环境模式检测到的分数损坏
+	scoreCorruption error
+	// 分区搜索使用的分区器
+	partitioner solution.SolutionPartitioner
+	// 就近选择使用的距离度量，分区求解器沿用同一度量
+	nearbyMeter nearby.NearbyDistanceMeter
+	// 最佳解决方案改进时的回调
+	bestSolutionListeners []BestSolutionChangedListener
+	// 等待应用的问题变更
+	problemChanges   []ProblemChange
+	problemChangesMu sync.Mutex
+	// 约束权重覆盖，以及应用覆盖前分数指导器的约束配置
+	constraintWeightOverrides *constraint.ConstraintWeightOverrides
+	baseConstraintManager     api.IConstraintConfigure
+
+	terminated        bool
+	terminateMu       sync.Mutex
+	terminationReason TerminationReason
+	bestSolution      api.ISolution
+	bestScore         api.IScore
+	// 最佳解决方案的变量值，工作解决方案在最佳之后继续被修改，求解结束时恢复
+	bestSnapshot *variableSnapshot
 
 	ctx    context.Context
 	cancel context.CancelFunc
 }
 
-func NewDefaultSolver(cfg *config.SolverConfig, scoreDirector api.IScoreDirector) *DefaultSolver {
+// NewDefaultSolver 创建求解器，配置校验失败时返回所有问题
+func NewDefaultSolver(cfg *config.SolverConfig, scoreDirector api.IScoreDirector) (*DefaultSolver, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid solver config: %w", err)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	solver := &DefaultSolver{
 		config:        cfg,
 		scoreDirector: scoreDirector,
+		termination:   termination.Build(cfg.Termination),
+		scope:         termination.NewScope(),
+		random:        rand.New(rand.NewSource(cfg.RandomSeed)),
 		ctx:           ctx,
 		cancel:        cancel,
 	}
@@ -45,32 +83,141 @@ func NewDefaultSolver(cfg *config.SolverConfig, scoreDirector api.IScoreDirector
 		cfg.LocalSearchConfig.TabuSearchConfig.MaxFrequency,
 	)
 	solver.tabuAcceptor = tabu.NewTabuSearchAcceptor(
-		cfg.LocalSearchConfig.TabuSearchConfig.MaxFrequency,
-		cfg.LocalSearchConfig.TabuSearchConfig.MaxFrequency,
+		cfg.LocalSearchConfig.TabuSearchConfig.MinTabuSize,
+		cfg.LocalSearchConfig.TabuSearchConfig.MaxTabuSize,
 		aspirationConfig,
 	)
-	solver.moveSelector = move.NewDefaultMoveSelector(cfg, scoreDirector)
-	return solver
+	if director, ok := scoreDirector.(constraintManagerProvider); ok {
+		if err := score.CheckConstraintScoreTypes(director.GetConstraintManager()); err != nil {
+			return nil, fmt.Errorf("invalid constraints: %w", err)
+		}
+	}
+	moveSelector := move.NewDefaultMoveSelector(cfg, scoreDirector, solver.random)
+	solver.moveSelector = moveSelector
+	if solver.isAssertMode() {
+		director, ok := scoreDirector.(constraintManagerProvider)
+		if !ok {
+			return nil, fmt.Errorf("environment mode %s requires a score director exposing its constraints, got %T", cfg.EnvironmentMode, scoreDirector)
+		}
+		solver.asserter = score.NewScoreAsserter(director.GetConstraintManager())
+		if cfg.EnvironmentMode == config.EnvironmentModeFullAssert {
+			moveSelector.SetScoreAsserter(solver.asserter)
+		}
+	}
+	return solver, nil
+}
+
+// 能够提供约束配置的分数指导器，断言环境模式需要据此从头计算分数
+type constraintManagerProvider interface {
+	GetConstraintManager() api.IConstraintConfigure
+}
+
+// checkScoreType 检查求解器与各阶段的终止条件与解决方案的分数类型一致
+func (s *DefaultSolver) checkScoreType(initialScore api.IScore) error {
+	terminations := []termination.Termination{
+		s.termination,
+		termination.Build(s.config.ConstructionHeuristicConfig.Termination),
+		termination.Build(s.config.LocalSearchConfig.Termination),
+		termination.Build(s.config.ExhaustiveSearchConfig.Termination),
+		termination.Build(s.config.PartitionedSearchConfig.Termination),
+	}
+	for _, t := range terminations {
+		if err := termination.CheckScoreType(t, initialScore); err != nil {
+			return fmt.Errorf("termination: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *DefaultSolver) isAssertMode() bool {
+	switch s.config.EnvironmentMode {
+	case config.EnvironmentModeFastAssert, config.EnvironmentModeStepAssert, config.EnvironmentModeFullAssert:
+		return true
+	}
+	return false
 }
 
 func (s *DefaultSolver) Solve(problem api.ISolution) (api.ISolution, error) {
-	s.init(problem)
-	// 设置工作解
-	s.scoreDirector.SetWorkingSolution(problem)
+	return s.SolveContext(context.Background(), problem)
+}
 
+// SolveContext 求解问题，上下文的取消与截止时间会传递到构造启发式、移动选择与分数计算
+// 上下文取消或超时时返回目前的最佳解决方案与上下文的错误，终止原因可通过 GetTerminationReason 获取
+func (s *DefaultSolver) SolveContext(ctx context.Context, problem api.ISolution) (api.ISolution, error) {
+	solveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	if s.config.TimeLimit > 0 {
-		ctx, cancel := context.WithTimeout(s.ctx, time.Duration(s.config.TimeLimit)*time.Second)
-		defer cancel()
-		s.ctx = ctx
+		var timeoutCancel context.CancelFunc
+		solveCtx, timeoutCancel = context.WithTimeout(solveCtx, time.Duration(s.config.TimeLimit)*time.Second)
+		defer timeoutCancel()
 	}
+	s.terminateMu.Lock()
+	s.ctx = solveCtx
+	s.cancel = cancel
+	s.terminateMu.Unlock()
 
-	// 构造初始解
-	solution := s.constructInitialSolution(problem)
-	s.updateBestSolution(solution)
+	if s.config.NearbySelection && s.nearbyMeter == nil {
+		return nil, errors.New("nearby selection is enabled but no distance meter is set, call SetNearbyDistanceMeter before solving")
+	}
+	if err := s.applyConstraintWeightOverrides(problem); err != nil {
+		return nil, err
+	}
+	// 先检查分数类型，类型不一致时不启动求解，也不通知最佳解决方案监听器
+	initialScore := s.scoreDirector.Calculate(problem)
+	if initialScore == nil {
+		return nil, fmt.Errorf("score director %T returned no initial score, check that all constraints use the same score type", s.scoreDirector)
+	}
+	if err := s.checkScoreType(initialScore); err != nil {
+		return nil, err
+	}
+	s.init(problem, initialScore)
+	// 设置工作解
+	s.scoreDirector.SetWorkingSolution(problem)
+	// 应用求解开始前添加的问题变更
+	if _, err := s.applyProblemChanges(problem); err != nil {
+		return nil, err
+	}
 
-	// 使用局部搜索进行改进解
-	if s.config.LocalSearch {
-		solution = s.localSearch(solution)
+	switch {
+	case s.config.PartitionedSearch:
+		// 分区搜索代替构造启发式与局部搜索
+		if err := s.partitionedSearch(problem); err != nil {
+			return s.bestSolution, fmt.Errorf("partitioned search: %w", err)
+		}
+		s.updateBestSolution(problem)
+	case s.config.ExhaustiveSearch:
+		// 穷举搜索代替构造启发式与局部搜索
+		s.updateBestSolution(s.exhaustiveSearch(problem))
+	default:
+		if err := s.runPhases(problem); err != nil {
+			return s.bestSolution, err
+		}
+		// 求解期间添加了问题变更时，应用变更后从修改后的工作解决方案重新运行所有阶段
+		for {
+			applied, err := s.applyProblemChanges(problem)
+			if err != nil {
+				// 变更后的问题没有有效的最佳解决方案
+				return nil, err
+			}
+			if !applied {
+				break
+			}
+			if err := s.runPhases(problem); err != nil {
+				return s.bestSolution, err
+			}
+		}
+	}
+	s.restoreBestSolution()
+
+	s.resolveTerminationReason(ctx)
+	if s.scoreCorruption != nil {
+		return s.bestSolution, s.scoreCorruption
+	}
+	if err := s.moveSelectionError(); err != nil {
+		return s.bestSolution, fmt.Errorf("move selection: %w", err)
+	}
+	if err := ctx.Err(); err != nil && !s.IsTerminated() {
+		return s.bestSolution, err
 	}
 	return s.bestSolution, nil
 }
@@ -82,6 +229,39 @@ func (s *DefaultSolver) Stop() {
 	s.cancel()
 }
 
+// GetTerminationReason 获取最近一次求解终止的原因
+func (s *DefaultSolver) GetTerminationReason() TerminationReason {
+	s.terminateMu.Lock()
+	defer s.terminateMu.Unlock()
+	return s.terminationReason
+}
+
+// setTerminationReason 记录终止原因，求解期间可能被其他协程通过 GetTerminationReason 读取
+func (s *DefaultSolver) setTerminationReason(reason TerminationReason) {
+	s.terminateMu.Lock()
+	defer s.terminateMu.Unlock()
+	s.terminationReason = reason
+}
+
+// resolveTerminationReason 根据调用方上下文与求解状态确定终止原因
+// 终止条件在阶段中记录，这里只处理优先级更高的原因
+func (s *DefaultSolver) resolveTerminationReason(callerCtx context.Context) {
+	switch {
+	case s.scoreCorruption != nil:
+		s.setTerminationReason(TerminationReasonScoreCorruption)
+	case s.IsTerminated():
+		s.setTerminationReason(TerminationReasonStopped)
+	case errors.Is(callerCtx.Err(), context.Canceled):
+		s.setTerminationReason(TerminationReasonCanceled)
+	case errors.Is(callerCtx.Err(), context.DeadlineExceeded):
+		s.setTerminationReason(TerminationReasonDeadlineExceeded)
+	case s.ctx.Err() != nil:
+		s.setTerminationReason(TerminationReasonTimeLimit)
+	case s.GetTerminationReason() == TerminationReasonNone:
+		s.setTerminationReason(TerminationReasonCompleted)
+	}
+}
+
 func (s *DefaultSolver) IsTerminated() bool {
 	s.terminateMu.Lock()
 	defer s.terminateMu.Unlock()
@@ -92,20 +272,71 @@ func (s *DefaultSolver) GetBestSolution() api.ISolution {
 	return s.bestSolution
 }
 
-func (s *DefaultSolver) init(problem api.ISolution) {
+// SetTermination 设置求解器级别的终止条件，覆盖终止配置
+func (s *DefaultSolver) SetTermination(t termination.Termination) {
+	s.termination = t
+}
+
+// SetNearbyDistanceMeter 设置就近选择使用的距离度量，需要同时启用 SolverConfig.NearbySelection
+func (s *DefaultSolver) SetNearbyDistanceMeter(meter nearby.NearbyDistanceMeter) {
+	s.nearbyMeter = meter
+	if selector, ok := s.moveSelector.(*move.DefaultMoveSelector); ok {
+		selector.SetNearbyDistanceMeter(meter)
+	}
+}
+
+// isSolverTerminated 检查求解器是否应当终止
+func (s *DefaultSolver) isSolverTerminated() bool {
+	if s.IsTerminated() || s.ctx.Err() != nil || s.scoreCorruption != nil {
+		return true
+	}
+	if s.termination != nil && s.termination.IsTerminated(s.scope) {
+		s.setTerminationReason(TerminationReasonTerminationCondition)
+		return true
+	}
+	return false
+}
+
+// isPhaseTerminated 检查阶段是否应当终止
+func (s *DefaultSolver) isPhaseTerminated(phaseTermination termination.Termination, phaseScope *termination.Scope) bool {
+	if s.isSolverTerminated() {
+		return true
+	}
+	if phaseTermination != nil && phaseTermination.IsTerminated(phaseScope) {
+		s.setTerminationReason(TerminationReasonTerminationCondition)
+		return true
+	}
+	return false
+}
+
+func (s *DefaultSolver) init(problem api.ISolution, initialScore api.IScore) {
 	s.terminateMu.Lock()
 	s.terminated = false
+	s.terminationReason = TerminationReasonNone
 	s.terminateMu.Unlock()
+	s.scoreCorruption = nil
+	// 清除上次求解检测到的分数损坏，移动选择器与求解器共用随机源，随后按环境模式重新设置种子
+	if selector, ok := s.moveSelector.(*move.DefaultMoveSelector); ok {
+		selector.Reset()
+	}
+	if s.config.EnvironmentMode == config.EnvironmentModeNonReproducible {
+		s.random.Seed(time.Now().UnixNano())
+	} else {
+		// 每次求解都从相同的随机种子开始，保证相同的种子、配置与输入得到相同的步骤序列
+		s.random.Seed(s.config.RandomSeed)
+	}
 
 	if s.tabuAcceptor != nil {
 		s.tabuAcceptor.Clear()
 	}
-	initailScore := s.scoreDirector.Calculate(problem)
-	problem.SetScore(initailScore)
+	problem.SetScore(initialScore)
 	s.bestSolution = problem
-	s.bestScore = initailScore
+	s.bestScore = initialScore
+	s.bestSnapshot = snapshotVariables(problem)
+	s.scope.Start(initialScore)
 
 	s.currentMove = nil
+	s.fireBestSolutionChanged()
 }
 
 func (s *DefaultSolver) updateBestSolution(solution api.ISolution) {
@@ -114,7 +345,18 @@ func (s *DefaultSolver) updateBestSolution(solution api.ISolution) {
 	if s.bestScore == nil || score.CompareTo(s.bestScore) > 0 {
 		s.bestSolution = solution
 		s.bestScore = score
+		s.bestSnapshot = snapshotVariables(solution)
+		s.fireBestSolutionChanged()
+	}
+}
+
+// restoreBestSolution 将最佳解决方案的变量值恢复到工作解决方案
+func (s *DefaultSolver) restoreBestSolution() {
+	if s.bestSnapshot == nil {
+		return
 	}
+	s.bestSnapshot.restore(s.scoreDirector)
+	s.bestSolution.SetScore(s.bestScore)
 }
 
 func (s *DefaultSolver) localSearch(solution api.ISolution) api.ISolution {
@@ -124,54 +366,104 @@ func (s *DefaultSolver) localSearch(solution api.ISolution) api.ISolution {
 	lsConfig := s.config.LocalSearchConfig
 	temperature := lsConfig.InitialTemperature
 
-	stepCount := 0
-	lastImprovement := 0
-	for !s.IsTerminated() {
-		if s.checkTermination(stepCount, lastImprovement) {
-			break
-		}
+	phaseTermination := termination.Build(lsConfig.Termination)
+	phaseScope := termination.NewChildScope(s.scope)
+	phaseScope.Start(currentScore)
+	if selector, ok := s.moveSelector.(*move.DefaultMoveSelector); ok {
+		selector.PhaseStarted(currentSolution)
+	}
+	// 有问题变更时结束阶段，由 SolveContext 应用变更后重新开始
+	for !s.isPhaseTerminated(phaseTermination, phaseScope) && !s.hasProblemChanges() {
 		move := s.selectMove(currentSolution)
-		if move == nil {
+		if s.checkMoveSelectorAssertion() || move == nil {
 			break
 		}
 		s.currentMove = move
 
-		s.scoreDirector.BeforeVariableChanged(nil) // 需要正确实现
+		var before *score.ScoreSnapshot
+		if s.isStepAssertMode() {
+			before = s.asserter.Snapshot(currentSolution)
+		}
 		move.Execute(currentSolution)
-		s.scoreDirector.AfterVariableChanged(nil) // 需要正确实现
-		newScore := s.scoreDirector.Calculate(currentSolution)
+		newScore, err := score.CalculateContext(s.ctx, s.scoreDirector, currentSolution)
+		if err != nil {
+			// 上下文已取消，撤销未评估完的移动
+			move.Undo(currentSolution)
+			break
+		}
+		if s.config.EnvironmentMode == config.EnvironmentModeFullAssert {
+			if s.failOnCorruption(s.asserter.AssertWorkingScore(before, newScore, currentSolution, move, "after move")) {
+				break
+			}
+		}
+		phaseScope.MoveEvaluated()
 		accept := s.acceptMove(currentScore, newScore, temperature, &lsConfig)
 		if accept {
 			currentScore = newScore
 			s.updateBestSolution(currentSolution)
-			if newScore.CompareTo(s.bestScore) > 0 {
-				lastImprovement = 0
-			}
+			s.stepTaken(move)
 		} else {
 			move.Undo(currentSolution)
+			if before != nil && s.failOnCorruption(s.asserter.AssertUndo(before, currentSolution, move)) {
+				break
+			}
+		}
+		if s.asserter != nil {
+			if s.failOnCorruption(s.asserter.AssertWorkingScore(before, currentScore, currentSolution, move, "after step")) {
+				break
+			}
+		}
+		// 只有被接受的移动才算一步，被拒绝并撤销的移动只计入移动数
+		if accept {
+			phaseScope.StepEnded(currentScore)
 		}
-		stepCount++
 		temperature *= lsConfig.CoolingRate
 	}
 	return s.bestSolution
 }
 
-func (s *DefaultSolver) checkTermination(stepCount int, lastImprovement int) bool {
-	termConfig := s.config.Termination
-	if termConfig.StepCountLimit > 0 && stepCount >= termConfig.StepCountLimit {
-		return true
+// isStepAssertMode 是否需要在每步前保存快照，以检查撤销后的状态
+func (s *DefaultSolver) isStepAssertMode() bool {
+	return s.config.EnvironmentMode == config.EnvironmentModeStepAssert ||
+		s.config.EnvironmentMode == config.EnvironmentModeFullAssert
+}
+
+// failOnCorruption 记录检测到的分数损坏，返回是否应当终止
+func (s *DefaultSolver) failOnCorruption(err error) bool {
+	if err == nil {
+		return false
 	}
-	if termConfig.UnimprovedStepCountLimit > 0 && (stepCount-lastImprovement) >= termConfig.UnimprovedStepCountLimit {
-		return true
+	s.scoreCorruption = err
+	return true
+}
+
+// checkMoveSelectorAssertion 检查移动选择器在评估移动时是否检测到分数损坏
+func (s *DefaultSolver) checkMoveSelectorAssertion() bool {
+	selector, ok := s.moveSelector.(*move.DefaultMoveSelector)
+	if !ok {
+		return false
 	}
-	if termConfig.BestScoreLimit != nil && s.bestScore.CompareTo(termConfig.BestScoreLimit) >= 0 {
-		return true
+	return s.failOnCorruption(selector.AssertionError())
+}
+
+// moveSelectionError 获取移动选择器选择移动时遇到的错误
+func (s *DefaultSolver) moveSelectionError() error {
+	selector, ok := s.moveSelector.(*move.DefaultMoveSelector)
+	if !ok {
+		return nil
+	}
+	return selector.SelectionError()
+}
+
+// stepTaken 通知移动选择器工作解决方案接受了移动
+func (s *DefaultSolver) stepTaken(m api.IMove) {
+	if selector, ok := s.moveSelector.(*move.DefaultMoveSelector); ok {
+		selector.StepTaken(m)
 	}
-	return false
 }
 
 func (s *DefaultSolver) selectMove(solution api.ISolution) api.IMove {
-	return s.moveSelector.SelectMove(solution)
+	return s.moveSelector.SelectMove(s.ctx, solution)
 }
 
 func (s *DefaultSolver) acceptMove(currentScore, newScore api.IScore, temperature float64, lsConfig *config.LocalSearchConfig) bool {
@@ -189,9 +481,9 @@ func (s *DefaultSolver) acceptSimulatedAnnealing(currentScore, newScore api.ISco
 	if newScore.CompareTo(currentScore) >= 0 {
 		return true
 	}
-	delta := float64(newScore.CompareTo(currentScore))
+	delta := scoredef.Difference(newScore, currentScore)
 	probability := math.Exp(delta / temperature)
-	return rand.Float64() < probability
+	return s.random.Float64() < probability
 }
 
 func (s *DefaultSolver) acceptTabuSearch(newScore api.IScore, lsConfig *config.LocalSearchConfig) bool {
@@ -209,136 +501,37 @@ func (s *DefaultSolver) acceptTabuSearch(newScore api.IScore, lsConfig *config.L
 	return accept
 }
 
-func (s *DefaultSolver) constructInitialSolution(problem api.ISolution) api.ISolution {
-	switch s.config.ConstructionHeuristic {
-	case "FIRST_FIT":
-		return s.firstFit(problem)
-	case "FIRST_FIT_DECREASING":
-		return s.firstFitDecreasing(problem)
-	default:
-		return s.firstFit(problem)
-	}
-}
-
-// firstFit 实现最先适应构造法
-func (s *DefaultSolver) firstFit(problem api.ISolution) api.ISolution {
-	// 获取所有规划实体
-	entities := s.getPlanningEntities(problem)
-
-	// 对每个实体进行赋值
-	for _, entity := range entities {
-		// 获取实体的所有规划变量
-		variables := entity.GetPlanningVariables()
-
-		for _, variable := range variables {
-			// 获取变量的值域
-			valueRange := variable.GetValueRange()
-			iterator := valueRange.CreateIterator()
-
-			// 找到第一个可行值
-			for iterator.HasNext() {
-				value := iterator.Next()
-				variable.SetValue(value)
-
-				// 计算当前解的得分
-				score := s.scoreDirector.Calculate(problem)
-				if score.IsFeasible() {
-					break
-				}
-			}
-		}
+// runPhases 依次运行构造启发式与局部搜索阶段
+func (s *DefaultSolver) runPhases(problem api.ISolution) error {
+	// 构造初始解
+	solution, err := s.constructInitialSolution(problem)
+	if err != nil {
+		return fmt.Errorf("construction heuristic: %w", err)
 	}
-
-	return problem
-}
-
-// firstFitDecreasing 实现最先适应递减构造法
-func (s *DefaultSolver) firstFitDecreasing(problem api.ISolution) api.ISolution {
-	// 获取所有规划实体
-	entities := s.getPlanningEntities(problem)
-
-	// 对实体进行排序（按照某种启发式规则）
-	sort.Slice(entities, func(i, j int) bool {
-		return s.compareEntities(entities[i], entities[j])
-	})
-
-	// 使用排序后的实体列表执行firstFit
-	for _, entity := range entities {
-		variables := entity.GetPlanningVariables()
-
-		for _, variable := range variables {
-			valueRange := variable.GetValueRange()
-			iterator := valueRange.CreateIterator()
-
-			bestValue := iterator.Next()
-			bestScore := s.evaluateAssignment(problem, variable, bestValue)
-
-			// 尝试所有可能的值，选择最好的
-			for iterator.HasNext() {
-				value := iterator.Next()
-				score := s.evaluateAssignment(problem, variable, value)
-
-				if score.CompareTo(bestScore) > 0 {
-					bestValue = value
-					bestScore = score
-				}
-			}
-
-			variable.SetValue(bestValue)
-		}
+	s.updateBestSolution(solution)
+	if s.asserter != nil {
+		s.failOnCorruption(s.asserter.AssertWorkingScore(nil, solution.GetScore(), solution, nil, "after construction heuristic"))
 	}
 
-	return problem
-}
-
-// getPlanningEntities 获取问题中的所有规划实体
-func (s *DefaultSolver) getPlanningEntities(solution api.ISolution) []api.IPlanningEntity {
-	// 从解决方案中提取所有规划实体
-	var entities []api.IPlanningEntity
-
-	// 获取问题事实
-	facts := solution.GetProblemFacts()
-	for _, fact := range facts {
-		if entity, ok := fact.(api.IPlanningEntity); ok {
-			entities = append(entities, entity)
-		}
+	// 使用局部搜索进行改进解
+	if s.config.LocalSearch && !s.isSolverTerminated() {
+		s.localSearch(solution)
 	}
-
-	return entities
+	return nil
 }
 
-// compareEntities 比较两个实体的优先级
-func (s *DefaultSolver) compareEntities(a, b api.IPlanningEntity) bool {
-	// 获取实体的规划变量数量作为优先级依据
-	aVars := len(a.GetPlanningVariables())
-	bVars := len(b.GetPlanningVariables())
-
-	// 变量越多，优先级越高
-	if aVars != bVars {
-		return aVars > bVars
-	}
-
-	// TODO: 可以添加更多的启发式规则
-	return false
+// exhaustiveSearch 使用穷举搜索求得最佳解
+func (s *DefaultSolver) exhaustiveSearch(problem api.ISolution) api.ISolution {
+	exhaustiveSearch := heuristic.NewExhaustiveSearch(&s.config.ExhaustiveSearchConfig, s.scoreDirector)
+	exhaustiveSearch.SetParentScope(s.scope)
+	exhaustiveSearch.SetTerminationFunc(s.isSolverTerminated)
+	return exhaustiveSearch.Search(s.ctx, problem)
 }
 
-// evaluateAssignment 评估单个赋值的效果
-func (s *DefaultSolver) evaluateAssignment(solution api.ISolution, variable api.IPlanningVariable, value interface{}) api.IScore {
-	// 保存原始值
-	originalValue := variable.GetValue()
-
-	// 尝试新值
-	s.scoreDirector.BeforeVariableChanged(variable)
-	variable.SetValue(value)
-	s.scoreDirector.AfterVariableChanged(variable)
-
-	// 计算得分
-	score := s.scoreDirector.Calculate(solution)
-
-	// 恢复原始值
-	s.scoreDirector.BeforeVariableChanged(variable)
-	variable.SetValue(originalValue)
-	s.scoreDirector.AfterVariableChanged(variable)
-
-	return score
+// constructInitialSolution 使用构造启发式生成初始解
+func (s *DefaultSolver) constructInitialSolution(problem api.ISolution) (api.ISolution, error) {
+	constructionHeuristic := heuristic.NewConstructionHeuristic(&s.config.ConstructionHeuristicConfig, s.scoreDirector)
+	constructionHeuristic.SetParentScope(s.scope)
+	constructionHeuristic.SetTerminationFunc(s.isSolverTerminated)
+	return constructionHeuristic.Construct(s.ctx, problem)
 }
@@ -0,0 +1,66 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+)
+
+// noScoreDirector 与分数类型不一致的约束一样不返回分数
+type noScoreDirector struct {
+	api.IScoreDirector
+}
+
+func (d *noScoreDirector) Calculate(api.ISolution) api.IScore { return nil }
+
+func newCountingSolver(t *testing.T, cfg *config.SolverConfig, scoreDirector api.IScoreDirector) (*DefaultSolver, *int) {
+	t.Helper()
+	s, err := NewDefaultSolver(cfg, scoreDirector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notified := 0
+	s.AddBestSolutionChangedListener(func(api.ISolution, api.IScore) {
+		notified++
+	})
+	return s, &notified
+}
+
+func TestSolveChecksTerminationScoreTypeBeforeStarting(t *testing.T) {
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	cfg.Termination = config.TerminationConfig{BestScoreLimit: simple.NewSimpleScore(0, 0)}
+	s, notified := newCountingSolver(t, cfg, codec.NewScoreDirector(nqueens.Codec{}))
+
+	problem := nqueens.NewBoard(4)
+	_, err := s.Solve(problem)
+	var mismatch *scoredef.ScoreTypeMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("got error %v, want *ScoreTypeMismatchError", err)
+	}
+	if *notified != 0 {
+		t.Errorf("best solution listeners were notified %d time(s) before the score type check", *notified)
+	}
+	if problem.GetScore() != nil {
+		t.Errorf("problem score was set to %s before the score type check", problem.GetScore().ToShortString())
+	}
+}
+
+func TestSolveRejectsMissingInitialScore(t *testing.T) {
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	s, notified := newCountingSolver(t, cfg, &noScoreDirector{IScoreDirector: codec.NewScoreDirector(nqueens.Codec{})})
+
+	if _, err := s.Solve(nqueens.NewBoard(4)); err == nil {
+		t.Fatal("got no error for a score director without a score")
+	}
+	if *notified != 0 {
+		t.Errorf("best solution listeners were notified %d time(s)", *notified)
+	}
+}
@@ -0,0 +1,209 @@
+package solver
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/termination"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+// newSpreadProblem 实体取值 0 到 9，相邻实体的取值越接近软分数惩罚越多，取值离实体的目标值越远也惩罚越多
+// 只有软约束，所有移动都可行；构造启发式逐个贪心赋值得不到最优解，局部搜索还能继续改进
+func newSpreadProblem(entityCount int) (*colorSolution, api.IScoreDirector) {
+	values := make([]interface{}, 10)
+	for i := range values {
+		values[i] = i
+	}
+	valueRange := valuerange.NewListValueRange(values...)
+	problem := &colorSolution{}
+	for range entityCount {
+		problem.entities = append(problem.entities, &colorEntity{variable: &colorVariable{valueRange: valueRange}})
+	}
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("spread"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			penalty := 0
+			entities := s.GetPlanningEntities()
+			for i, entity := range entities {
+				a, ok := entity.GetPlanningVariables()[0].GetValue().(int)
+				if !ok {
+					continue
+				}
+				penalty += abs(a - i*7%10)
+				if i == 0 {
+					continue
+				}
+				if b, ok := entities[i-1].GetPlanningVariables()[0].GetValue().(int); ok {
+					penalty += 3 * max(0, 4-abs(a-b))
+				}
+			}
+			return penalty
+		}),
+	))
+	return problem, score.NewScoreDirector(score.NewScoreCalculator(cm), cm)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// recordingScoreDirector 记录每次计算分数时的赋值
+type recordingScoreDirector struct {
+	api.IScoreDirector
+
+	mu    sync.Mutex
+	trace []string
+}
+
+func (d *recordingScoreDirector) Calculate(solution api.ISolution) api.IScore {
+	values := make([]interface{}, 0, len(solution.GetPlanningEntities()))
+	for _, entity := range solution.GetPlanningEntities() {
+		values = append(values, entity.GetPlanningVariables()[0].GetValue())
+	}
+	d.mu.Lock()
+	d.trace = append(d.trace, fmt.Sprint(values...))
+	d.mu.Unlock()
+	return d.IScoreDirector.Calculate(solution)
+}
+
+// solveTrace 的结果：每次计算分数时的赋值、每次最优解变化时的分数与最终的最优分数
+type solveTrace struct {
+	evaluations []string
+	bestScores  []string
+	bestScore   string
+}
+
+// solveRecorded 使用随机变更移动选择器与模拟退火求解，记录求解过程
+func solveRecorded(t *testing.T, seed int64) solveTrace {
+	t.Helper()
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = false
+	cfg.RandomSeed = seed
+	cfg.MoveSelector = config.MOVE_SELECTOR_RANDOM_CHANGE
+	cfg.LocalSearchConfig.InitialTemperature = 2
+	cfg.Termination = config.TerminationConfig{StepCountLimit: 150, MoveCountLimit: 2000}
+	problem, scoreDirector := newSpreadProblem(12)
+	recorder := &recordingScoreDirector{IScoreDirector: scoreDirector}
+	s, err := NewDefaultSolver(cfg, recorder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trace solveTrace
+	s.AddBestSolutionChangedListener(func(_ api.ISolution, bestScore api.IScore) {
+		trace.bestScores = append(trace.bestScores, bestScore.ToShortString())
+	})
+	best, err := s.Solve(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	trace.evaluations = recorder.trace
+	trace.bestScore = best.GetScore().ToShortString()
+	return trace
+}
+
+func TestSameRandomSeedIsReproducible(t *testing.T) {
+	first := solveRecorded(t, 7)
+	if len(first.bestScores) < 3 {
+		t.Fatalf("local search never improved the best solution: %v", first.bestScores)
+	}
+	second := solveRecorded(t, 7)
+	if !reflect.DeepEqual(first.evaluations, second.evaluations) {
+		t.Errorf("same seed evaluated different solutions: %d and %d evaluations", len(first.evaluations), len(second.evaluations))
+	}
+	if !reflect.DeepEqual(first.bestScores, second.bestScores) {
+		t.Errorf("same seed changed the best solution differently: %v and %v", first.bestScores, second.bestScores)
+	}
+	if first.bestScore != second.bestScore {
+		t.Errorf("same seed reached best scores %s and %s", first.bestScore, second.bestScore)
+	}
+
+	other := solveRecorded(t, 8)
+	if reflect.DeepEqual(first.evaluations, other.evaluations) {
+		t.Errorf("seeds 7 and 8 evaluated the same %d solutions", len(first.evaluations))
+	}
+}
+
+// recordingTermination 每次检查是否终止时记录工作解决方案的赋值，即每一步之后的状态
+type recordingTermination struct {
+	termination.Termination
+	solution api.ISolution
+	states   []string
+}
+
+func (r *recordingTermination) IsTerminated(scope *termination.Scope) bool {
+	values := make([]interface{}, 0, len(r.solution.GetPlanningEntities()))
+	for _, entity := range r.solution.GetPlanningEntities() {
+		values = append(values, entity.GetPlanningVariables()[0].GetValue())
+	}
+	r.states = append(r.states, fmt.Sprint(values...))
+	return r.Termination.IsTerminated(scope)
+}
+
+// solveSteps 使用确定的移动选择器求解，返回每步之后的赋值、最终的最优分数与克隆解决方案的次数
+func solveSteps(t *testing.T, moveSelector string, parallel bool) (*DefaultSolver, []string, string, int32) {
+	t.Helper()
+	cfg := config.NewDefalutSolverConfig()
+	cfg.Parallel = parallel
+	cfg.ParallelThreadCount = 4
+	cfg.RandomSeed = 3
+	cfg.MoveSelector = moveSelector
+	cfg.LocalSearchConfig.InitialTemperature = 2
+	problem, scoreDirector := newSpreadProblem(12)
+	problem.clones = &atomic.Int32{}
+	s, err := NewDefaultSolver(cfg, scoreDirector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := &recordingTermination{Termination: termination.NewMoveCountTermination(200), solution: problem}
+	s.SetTermination(recorder)
+	best, err := s.Solve(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, recorder.states, best.GetScore().ToShortString(), problem.clones.Load()
+}
+
+func TestParallelEvaluationMatchesSequential(t *testing.T) {
+	for _, moveSelector := range []string{config.MOVE_SELECTOR_BEST_FIT, config.MOVE_SELECTOR_CHANGE} {
+		t.Run(moveSelector, func(t *testing.T) {
+			sequential, sequentialStates, sequentialBest, sequentialClones := solveSteps(t, moveSelector, false)
+			parallel, parallelStates, parallelBest, parallelClones := solveSteps(t, moveSelector, true)
+			if sequentialClones != 0 || parallelClones == 0 {
+				t.Fatalf("got %d clones sequentially and %d in parallel, want moves evaluated on clones only in parallel", sequentialClones, parallelClones)
+			}
+			if steps := sequential.GetStepCount(); steps < 20 {
+				t.Fatalf("took only %d steps", steps)
+			}
+			for i := range min(len(sequentialStates), len(parallelStates)) {
+				if sequentialStates[i] != parallelStates[i] {
+					t.Errorf("state %d differs: sequential [%s], parallel [%s]", i, sequentialStates[i], parallelStates[i])
+					break
+				}
+			}
+			if len(sequentialStates) != len(parallelStates) {
+				t.Errorf("got %d states sequentially and %d in parallel", len(sequentialStates), len(parallelStates))
+			}
+			if sequentialBest != parallelBest {
+				t.Errorf("got best score %s sequentially and %s in parallel", sequentialBest, parallelBest)
+			}
+			if sequential.GetStepCount() != parallel.GetStepCount() || sequential.GetMoveCount() != parallel.GetMoveCount() {
+				t.Errorf("got %d steps and %d moves sequentially, %d steps and %d moves in parallel",
+					sequential.GetStepCount(), sequential.GetMoveCount(), parallel.GetStepCount(), parallel.GetMoveCount())
+			}
+		})
+	}
+}
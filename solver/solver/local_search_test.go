@@ -0,0 +1,257 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/constraint"
+	"github.com/kruily/go-timefold-solver/solver/nearby"
+	"github.com/kruily/go-timefold-solver/solver/score"
+	"github.com/kruily/go-timefold-solver/solver/valuerange"
+)
+
+type colorVariable struct {
+	value      interface{}
+	valueRange api.IValueRange
+}
+
+func (v *colorVariable) GetValue() interface{}          { return v.value }
+func (v *colorVariable) SetValue(value interface{})     { v.value = value }
+func (v *colorVariable) GetValueRange() api.IValueRange { return v.valueRange }
+
+type colorEntity struct {
+	variable *colorVariable
+}
+
+func (e *colorEntity) PlanningFilter() {}
+func (e *colorEntity) GetPlanningVariables() []api.IPlanningVariable {
+	return []api.IPlanningVariable{e.variable}
+}
+
+type colorSolution struct {
+	score    api.IScore
+	entities []api.IPlanningEntity
+	// 克隆次数，为 nil 时不计数
+	clones *atomic.Int32
+}
+
+func (s *colorSolution) GetScore() api.IScore                               { return s.score }
+func (s *colorSolution) SetScore(score api.IScore)                          { s.score = score }
+func (s *colorSolution) GetPlanningEntities() []api.IPlanningEntity         { return s.entities }
+func (s *colorSolution) SetPlanningEntities(entities []api.IPlanningEntity) { s.entities = entities }
+func (s *colorSolution) GetProblemFacts() []interface{}                     { return nil }
+func (s *colorSolution) SetProblemFacts(facts []interface{})                {}
+
+func (s *colorSolution) CloneSolution() api.ISolution {
+	if s.clones != nil {
+		s.clones.Add(1)
+	}
+	clone := &colorSolution{score: s.score, clones: s.clones}
+	for _, entity := range s.entities {
+		variable := entity.(*colorEntity).variable
+		clone.entities = append(clone.entities, &colorEntity{variable: &colorVariable{value: variable.value, valueRange: variable.valueRange}})
+	}
+	return clone
+}
+
+// newColorProblem 值域为红、绿、蓝三种颜色，相邻实体取相同的颜色时惩罚 1 个硬分数，每个取蓝色的实体惩罚 1 个软分数
+// 构造启发式交替使用红色与绿色得到最优解，之后可行的移动只有改为蓝色，分数都变差
+func newColorProblem(entityCount int, red, green, blue interface{}) (*colorSolution, api.IScoreDirector) {
+	valueRange := valuerange.NewListValueRange(red, green, blue)
+	problem := &colorSolution{}
+	for range entityCount {
+		problem.entities = append(problem.entities, &colorEntity{variable: &colorVariable{valueRange: valueRange}})
+	}
+	cm := constraint.NewConstraintManager()
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("adjacent equal"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.HARD),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			count := 0
+			entities := s.GetPlanningEntities()
+			for i := 1; i < len(entities); i++ {
+				a := entities[i-1].GetPlanningVariables()[0].GetValue()
+				b := entities[i].GetPlanningVariables()[0].GetValue()
+				if a != nil && reflect.DeepEqual(a, b) {
+					count++
+				}
+			}
+			return count
+		}),
+	))
+	cm.AddConstraint(constraint.NewConstraint(
+		constraint.WithName("blue"),
+		constraint.WithWeight(-1),
+		constraint.WithType(constraint.SOFT),
+		constraint.WithMatchWeightFunc(func(s api.ISolution) int {
+			count := 0
+			for _, entity := range s.GetPlanningEntities() {
+				if reflect.DeepEqual(entity.GetPlanningVariables()[0].GetValue(), blue) {
+					count++
+				}
+			}
+			return count
+		}),
+	))
+	return problem, score.NewScoreDirector(score.NewScoreCalculator(cm), cm)
+}
+
+func TestLocalSearchWithSliceValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		moveSelector string
+		nearby       bool
+	}{
+		{"first fit", config.MOVE_SELECTOR_FIRST_FIT, false},
+		{"best fit", config.MOVE_SELECTOR_BEST_FIT, false},
+		{"change", config.MOVE_SELECTOR_CHANGE, false},
+		{"random", config.MOVE_SELECTOR_RANDOM, false},
+		{"random change", config.MOVE_SELECTOR_RANDOM_CHANGE, false},
+		{"ruin recreate", config.MOVE_SELECTOR_RUIN_RECREATE, false},
+		{"nearby random", config.MOVE_SELECTOR_RANDOM, true},
+		{"nearby random change", config.MOVE_SELECTOR_RANDOM_CHANGE, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefalutSolverConfig()
+			cfg.Parallel = false
+			cfg.MoveSelector = tt.moveSelector
+			cfg.NearbySelection = tt.nearby
+			cfg.Termination = config.TerminationConfig{StepCountLimit: 50}
+			problem, scoreDirector := newColorProblem(6, []string{"red"}, []string{"green"}, []string{"blue"})
+			s, err := NewDefaultSolver(cfg, scoreDirector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.nearby {
+				s.SetNearbyDistanceMeter(nearby.NearbyDistanceMeterFunc(func(origin, destination interface{}) float64 {
+					return 1
+				}))
+			}
+
+			best, err := s.Solve(problem)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, entity := range best.GetPlanningEntities() {
+				if entity.GetPlanningVariables()[0].GetValue() == nil {
+					t.Errorf("entity %d is unassigned", i)
+				}
+			}
+			if reason := s.GetTerminationReason(); reason == TerminationReasonNone {
+				t.Errorf("solving ended without a termination reason")
+			}
+		})
+	}
+}
+
+func TestLocalSearchCountsOnlyAcceptedMovesAsSteps(t *testing.T) {
+	solve := func(localSearch bool) *DefaultSolver {
+		t.Helper()
+		cfg := config.NewDefalutSolverConfig()
+		cfg.Parallel = false
+		// 变更选择器每次都选出第一个可行的移动（第一个实体改为蓝色）
+		cfg.MoveSelector = config.MOVE_SELECTOR_CHANGE
+		cfg.LocalSearch = localSearch
+		cfg.Termination = config.TerminationConfig{}
+		// 温度极低时变差的移动都被拒绝
+		cfg.LocalSearchConfig.InitialTemperature = 1e-9
+		// 按移动计数时未改进的步数先达到限制，阶段在 10 次移动后结束
+		cfg.LocalSearchConfig.Termination = config.TerminationConfig{UnimprovedStepCountLimit: 10, MoveCountLimit: 30}
+		problem, scoreDirector := newColorProblem(6, "red", "green", "blue")
+		s, err := NewDefaultSolver(cfg, scoreDirector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		best, err := s.Solve(problem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := best.GetScore().ToShortString(); got != "HardSoftScore[initScore=0, hardScore=0, softScore=0]" {
+			t.Fatalf("got best score %s, want the optimum", got)
+		}
+		return s
+	}
+	constructionOnly := solve(false)
+	withLocalSearch := solve(true)
+
+	if steps := withLocalSearch.GetStepCount() - constructionOnly.GetStepCount(); steps != 0 {
+		t.Errorf("local search took %d step(s), want 0 because every move is rejected", steps)
+	}
+	if moves := withLocalSearch.GetMoveCount() - constructionOnly.GetMoveCount(); moves != 30 {
+		t.Errorf("local search evaluated %d move(s), want the move count limit 30", moves)
+	}
+}
+
+// brokenUndoMove 将实体改为目标值，撤销时忘记恢复旧值
+type brokenUndoMove struct {
+	index  int
+	entity api.IPlanningEntity
+	value  interface{}
+}
+
+func (m *brokenUndoMove) Execute(api.ISolution) {
+	m.entity.GetPlanningVariables()[0].SetValue(m.value)
+}
+
+func (m *brokenUndoMove) Undo(api.ISolution)             {}
+func (m *brokenUndoMove) Accept(api.IScoreDirector) bool { return true }
+func (m *brokenUndoMove) String() string {
+	return fmt.Sprintf("brokenUndoMove(entity %d -> %v)", m.index, m.value)
+}
+
+// brokenUndoSelector 总是选出将第一个实体改为蓝色的移动
+type brokenUndoSelector struct{}
+
+func (brokenUndoSelector) SelectMove(_ context.Context, solution api.ISolution) api.IMove {
+	return &brokenUndoMove{entity: solution.GetPlanningEntities()[0], value: "blue"}
+}
+
+func (brokenUndoSelector) Reset() {}
+
+func TestAssertModesDetectBrokenUndo(t *testing.T) {
+	for _, mode := range []string{config.EnvironmentModeStepAssert, config.EnvironmentModeFullAssert} {
+		t.Run(mode, func(t *testing.T) {
+			cfg := config.NewDefalutSolverConfig()
+			cfg.Parallel = false
+			cfg.EnvironmentMode = mode
+			// 温度极低时改为蓝色的移动被拒绝并撤销
+			cfg.LocalSearchConfig.InitialTemperature = 1e-9
+			cfg.Termination = config.TerminationConfig{MoveCountLimit: 100}
+			problem, scoreDirector := newColorProblem(6, "red", "green", "blue")
+			s, err := NewDefaultSolver(cfg, scoreDirector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.moveSelector = brokenUndoSelector{}
+
+			_, err = s.Solve(problem)
+			var corruption *score.ScoreCorruptionError
+			if !errors.As(err, &corruption) {
+				t.Fatalf("got error %v, want *ScoreCorruptionError", err)
+			}
+			if corruption.Stage != "after undo" || corruption.Move != "brokenUndoMove(entity 0 -> blue)" {
+				t.Errorf("got corruption %s %q, want after undo of the broken move", corruption.Stage, corruption.Move)
+			}
+			if len(corruption.DivergedConstraints) != 1 || !strings.HasPrefix(corruption.DivergedConstraints[0], "blue (match weight 0 before move, 1 after undo)") {
+				t.Errorf("got diverged constraints %q, want only blue", corruption.DivergedConstraints)
+			}
+			for _, want := range []string{"brokenUndoMove(entity 0 -> blue)", "blue (match weight 0"} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not name %q", err, want)
+				}
+			}
+			if reason := s.GetTerminationReason(); reason != TerminationReasonScoreCorruption {
+				t.Errorf("got termination reason %v, want score corruption", reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+	"github.com/kruily/go-timefold-solver/solver/termination"
+)
+
+// SetSolutionPartitioner 设置分区搜索使用的分区器
+func (s *DefaultSolver) SetSolutionPartitioner(partitioner solution.SolutionPartitioner) {
+	s.partitioner = partitioner
+}
+
+// partitionedSearch 将解决方案拆分为分区并发求解，每个分区由独立的求解器按 SolverConfig 的阶段配置求解
+// 分区直接修改原解决方案中的实体，分区的分数变差时恢复该分区求解前的值
+func (s *DefaultSolver) partitionedSearch(problem api.ISolution) error {
+	if s.partitioner == nil {
+		return errors.New("no SolutionPartitioner set")
+	}
+	cloneable, ok := s.scoreDirector.(api.ICloneableScoreDirector)
+	if !ok {
+		return fmt.Errorf("score director %T is not cloneable", s.scoreDirector)
+	}
+	partitions := s.partitioner.SplitWorkingSolution(problem)
+	if err := checkPartitions(partitions); err != nil {
+		return err
+	}
+
+	limit := s.config.PartitionedSearchConfig.RunnablePartThreadLimit
+	if limit <= 0 || limit > len(partitions) {
+		limit = len(partitions)
+	}
+	runnable := make(chan struct{}, limit)
+	errs := make([]error, len(partitions))
+	var wg sync.WaitGroup
+	for i, partition := range partitions {
+		wg.Add(1)
+		go func(i int, partition *solution.SubSolution) {
+			defer wg.Done()
+			runnable <- struct{}{}
+			defer func() { <-runnable }()
+			if s.IsTerminated() || s.ctx.Err() != nil {
+				return
+			}
+			errs[i] = s.solvePartition(i, partition, cloneable.CloneScoreDirector())
+		}(i, partition)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// solvePartition 求解单个分区，分数没有提升时恢复分区内可移动实体的值
+func (s *DefaultSolver) solvePartition(index int, partition *solution.SubSolution, scoreDirector api.IScoreDirector) error {
+	partCfg := *s.config
+	partCfg.PartitionedSearch = false
+	// 求解时间由父求解器的上下文控制
+	partCfg.TimeLimit = 0
+	partCfg.RandomSeed = s.config.RandomSeed + int64(index)
+	if termination.Build(s.config.PartitionedSearchConfig.Termination) != nil {
+		partCfg.Termination = s.config.PartitionedSearchConfig.Termination
+	}
+	partSolver, err := NewDefaultSolver(&partCfg, scoreDirector)
+	if err != nil {
+		return fmt.Errorf("partition %d: %w", index, err)
+	}
+	if s.nearbyMeter != nil {
+		partSolver.SetNearbyDistanceMeter(s.nearbyMeter)
+	}
+
+	snapshot := snapshotVariables(partition)
+	initialScore := scoreDirector.Calculate(partition)
+	if _, err := partSolver.SolveContext(s.ctx, partition); err != nil && !errors.Is(err, s.ctx.Err()) {
+		snapshot.restore(scoreDirector)
+		return fmt.Errorf("partition %d: %w", index, err)
+	}
+	if scoreDirector.Calculate(partition).CompareTo(initialScore) < 0 {
+		snapshot.restore(scoreDirector)
+	}
+	return nil
+}
+
+// checkPartitions 检查分区之间没有共享可移动的实体
+func checkPartitions(partitions []*solution.SubSolution) error {
+	owners := make(map[api.IPlanningEntity]int)
+	for i, partition := range partitions {
+		for _, entity := range solution.GetMovablePlanningEntities(partition) {
+			if owner, ok := owners[entity]; ok {
+				return fmt.Errorf("entity %v is movable in both partition %d and partition %d", entity, owner, i)
+			}
+			owners[entity] = i
+		}
+	}
+	for i, partition := range partitions {
+		for entity := range partition.GetDirtyEntities() {
+			if owner, ok := owners[entity]; ok && owner != i {
+				return fmt.Errorf("entity %v is movable in partition %d and must not appear in partition %d", entity, owner, i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score"
+)
+
+// ProblemChange 问题变更，在求解协程中于两步之间调用
+// 变更可以增删实体或问题事实、修改变量的值，scoreDirector 为求解器的分数指导器
+// 变更前工作解决方案恢复为最佳解决方案，变更后求解器重置分数指导器与最佳解决方案，从修改后的工作解决方案重新运行构造启发式与局部搜索
+type ProblemChange func(workingSolution api.ISolution, scoreDirector api.IScoreDirector)
+
+// AddProblemChange 添加问题变更，可以在任意协程中调用
+// 求解期间变更在当前步结束后应用，未在求解时添加的变更在下一次求解开始时应用
+// 分区搜索与穷举搜索只在求解开始时应用变更
+func (s *DefaultSolver) AddProblemChange(change ProblemChange) {
+	s.problemChangesMu.Lock()
+	defer s.problemChangesMu.Unlock()
+	s.problemChanges = append(s.problemChanges, change)
+}
+
+// hasProblemChanges 是否有等待应用的问题变更
+func (s *DefaultSolver) hasProblemChanges() bool {
+	s.problemChangesMu.Lock()
+	defer s.problemChangesMu.Unlock()
+	return len(s.problemChanges) > 0
+}
+
+// applyProblemChanges 应用等待中的问题变更并重置分数与最佳解决方案，没有变更或求解器已终止时返回 false
+// 变更后的解决方案没有分数时（如变更引入了分数类型不一致的约束）返回错误
+func (s *DefaultSolver) applyProblemChanges(workingSolution api.ISolution) (bool, error) {
+	if s.isSolverTerminated() {
+		return false, nil
+	}
+	s.problemChangesMu.Lock()
+	changes := s.problemChanges
+	s.problemChanges = nil
+	s.problemChangesMu.Unlock()
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	// 局部搜索可能停在比最佳解决方案差的解上，变更作用于最佳解决方案
+	s.restoreBestSolution()
+	for _, change := range changes {
+		change(workingSolution, s.scoreDirector)
+	}
+	if director, ok := s.scoreDirector.(api.IResettableScoreDirector); ok {
+		director.ResetWorkingSolution(workingSolution)
+	} else {
+		s.scoreDirector.SetWorkingSolution(workingSolution)
+	}
+	if s.tabuAcceptor != nil {
+		s.tabuAcceptor.Clear()
+	}
+	s.currentMove = nil
+
+	// 变更前的最佳解决方案属于旧问题，以变更后的工作解决方案作为新的最佳解决方案
+	// 变更已修改工作解决方案，求解器此时被取消也需要计算变更后的分数
+	newScore, err := score.CalculateContext(context.WithoutCancel(s.ctx), s.scoreDirector, workingSolution)
+	if err != nil {
+		return false, fmt.Errorf("problem change: %w", err)
+	}
+	workingSolution.SetScore(newScore)
+	s.bestSolution = workingSolution
+	s.bestScore = newScore
+	s.bestSnapshot = snapshotVariables(workingSolution)
+	s.scope.ResetBestScore(newScore)
+	s.fireBestSolutionChanged()
+	return true, nil
+}
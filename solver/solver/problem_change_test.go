@@ -0,0 +1,130 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/examples/nqueens"
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/codec"
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+// triggeringScoreDirector 第 trigger 次计算分数时调用 onTrigger
+type triggeringScoreDirector struct {
+	api.IScoreDirector
+	calculations int
+	trigger      int
+	onTrigger    func()
+}
+
+func (d *triggeringScoreDirector) Calculate(solution api.ISolution) api.IScore {
+	d.calculations++
+	if d.calculations == d.trigger {
+		d.onTrigger()
+	}
+	return d.IScoreDirector.Calculate(solution)
+}
+
+func TestProblemChangeStartsFromBestSolution(t *testing.T) {
+	for trigger := 100; trigger <= 1000; trigger += 100 {
+		t.Run(fmt.Sprintf("trigger%d", trigger), func(t *testing.T) {
+			cfg := config.NewDefalutSolverConfig()
+			cfg.Parallel = false
+			// 高温的模拟退火接受变差的移动，变更时工作解决方案通常比最佳解决方案差
+			cfg.MoveSelector = config.MOVE_SELECTOR_RUIN_RECREATE
+			cfg.Termination = config.TerminationConfig{StepCountLimit: 200}
+			scoreDirector := &triggeringScoreDirector{IScoreDirector: codec.NewScoreDirector(nqueens.Codec{}), trigger: trigger}
+			s, err := NewDefaultSolver(cfg, scoreDirector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var bestScores []api.IScore
+			s.AddBestSolutionChangedListener(func(_ api.ISolution, bestScore api.IScore) {
+				bestScores = append(bestScores, bestScore)
+			})
+			changeIndex := -1
+			var workingScore api.IScore
+			scoreDirector.onTrigger = func() {
+				s.AddProblemChange(func(workingSolution api.ISolution, scoreDirector api.IScoreDirector) {
+					changeIndex = len(bestScores)
+					workingScore = scoreDirector.Calculate(workingSolution)
+				})
+			}
+
+			if _, err := s.Solve(nqueens.NewBoard(12)); err != nil {
+				t.Fatal(err)
+			}
+			if changeIndex < 1 || changeIndex >= len(bestScores) {
+				t.Fatalf("problem change was not applied during solving")
+			}
+			before := bestScores[changeIndex-1]
+			if workingScore.CompareTo(before) != 0 {
+				t.Errorf("problem change saw working score %s, want the best score %s", workingScore.ToShortString(), before.ToShortString())
+			}
+			// 变更不修改问题，变更后的最佳分数不应低于变更前的最佳分数
+			if after := bestScores[changeIndex]; after.CompareTo(before) < 0 {
+				t.Errorf("best score fell from %s to %s after the problem change", before.ToShortString(), after.ToShortString())
+			}
+			if final := s.GetBestSolution().GetScore(); final.CompareTo(before) < 0 {
+				t.Errorf("final best score %s is worse than the best score %s before the problem change", final.ToShortString(), before.ToShortString())
+			}
+		})
+	}
+}
+
+// breakableScoreDirector broken 为 true 时与分数类型不一致的约束一样不返回分数
+type breakableScoreDirector struct {
+	api.IScoreDirector
+	broken bool
+}
+
+func (d *breakableScoreDirector) Calculate(solution api.ISolution) api.IScore {
+	if d.broken {
+		return nil
+	}
+	return d.IScoreDirector.Calculate(solution)
+}
+
+func TestProblemChangeWithoutScoreFailsSolving(t *testing.T) {
+	for _, during := range []bool{false, true} {
+		t.Run(fmt.Sprintf("during%v", during), func(t *testing.T) {
+			cfg := config.NewDefalutSolverConfig()
+			cfg.Parallel = false
+			cfg.Termination = config.TerminationConfig{StepCountLimit: 100}
+			breakable := &breakableScoreDirector{IScoreDirector: codec.NewScoreDirector(nqueens.Codec{})}
+			scoreDirector := &triggeringScoreDirector{IScoreDirector: breakable, trigger: 50}
+			s, notified := newCountingSolver(t, cfg, scoreDirector)
+
+			notifiedBefore := -1
+			breakScore := func(api.ISolution, api.IScoreDirector) {
+				notifiedBefore = *notified
+				breakable.broken = true
+			}
+			if during {
+				scoreDirector.onTrigger = func() {
+					s.AddProblemChange(breakScore)
+				}
+			} else {
+				scoreDirector.onTrigger = func() {}
+				s.AddProblemChange(breakScore)
+			}
+
+			best, err := s.Solve(nqueens.NewBoard(8))
+			if err == nil || !strings.Contains(err.Error(), "problem change: score director") {
+				t.Fatalf("got error %v, want the missing score after the problem change", err)
+			}
+			if best != nil {
+				t.Errorf("got best solution %v for a problem without a score", best)
+			}
+			if notifiedBefore < 0 {
+				t.Fatal("problem change was not applied")
+			}
+			if *notified != notifiedBefore {
+				t.Errorf("best solution listeners were notified %d time(s) after the problem change", *notified-notifiedBefore)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+// SolverStatus 求解任务的状态
+type SolverStatus int
+
+const (
+	// 等待空闲的求解器
+	SolverStatusScheduled SolverStatus = iota
+	// 正在求解
+	SolverStatusActive
+	// 求解已结束（包括提前终止与失败）
+	SolverStatusEnded
+)
+
+func (s SolverStatus) String() string {
+	switch s {
+	case SolverStatusScheduled:
+		return "SOLVING_SCHEDULED"
+	case SolverStatusActive:
+		return "SOLVING_ACTIVE"
+	default:
+		return "NOT_SOLVING"
+	}
+}
+
+var (
+	// 问题 ID 已存在
+	ErrProblemExists = errors.New("problem already exists")
+	// 求解管理器已关闭
+	ErrSolverManagerClosed = errors.New("solver manager is closed")
+)
+
+// DefaultFinishedJobRetention 求解结束的任务默认保留的时间，之后从求解管理器中移除
+const DefaultFinishedJobRetention = time.Hour
+
+// SolverManagerOption 求解管理器选项
+type SolverManagerOption func(*SolverManager)
+
+// SolverManager 在后台协程中求解多个问题，每个问题使用独立的 DefaultSolver 与分数指导器
+type SolverManager struct {
+	config           *config.SolverConfig
+	newScoreDirector func() api.IScoreDirector
+	// 创建求解器后的自定义设置，如距离度量、分区器
+	customizer func(*DefaultSolver)
+	// 同时求解的问题数，超过时排队等待
+	slots chan struct{}
+	// 求解结束的任务保留的时间，不大于 0 时保留到被移除
+	finishedJobRetention time.Duration
+
+	mu     sync.Mutex
+	jobs   map[string]*SolverJob
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewSolverManager 创建求解管理器，每个问题求解前调用 newScoreDirector 创建分数指导器
+func NewSolverManager(cfg *config.SolverConfig, newScoreDirector func() api.IScoreDirector, options ...SolverManagerOption) (*SolverManager, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	manager := &SolverManager{
+		config:               cfg,
+		newScoreDirector:     newScoreDirector,
+		slots:                make(chan struct{}, 1),
+		finishedJobRetention: DefaultFinishedJobRetention,
+		jobs:                 make(map[string]*SolverJob),
+	}
+	for _, option := range options {
+		option(manager)
+	}
+	return manager, nil
+}
+
+// WithParallelSolverCount 设置同时求解的问题数，默认为 1
+func WithParallelSolverCount(count int) SolverManagerOption {
+	return func(manager *SolverManager) {
+		if count > 0 {
+			manager.slots = make(chan struct{}, count)
+		}
+	}
+}
+
+// WithFinishedJobRetention 设置求解结束的任务保留的时间，默认为 DefaultFinishedJobRetention，不大于 0 时保留到被移除
+func WithFinishedJobRetention(retention time.Duration) SolverManagerOption {
+	return func(manager *SolverManager) {
+		manager.finishedJobRetention = retention
+	}
+}
+
+// WithSolverCustomizer 设置创建求解器后的自定义设置
+func WithSolverCustomizer(customizer func(*DefaultSolver)) SolverManagerOption {
+	return func(manager *SolverManager) {
+		manager.customizer = customizer
+	}
+}
+
+// Solve 提交问题并在后台求解，立即返回求解任务
+// 求解期间获取最佳解决方案需要问题实现 api.ICloneableSolution，否则只能在求解结束后获取
+func (m *SolverManager) Solve(problemID string, problem api.ISolution) (*SolverJob, error) {
+	s, err := NewDefaultSolver(m.config, m.newScoreDirector())
+	if err != nil {
+		return nil, err
+	}
+	if m.customizer != nil {
+		m.customizer(s)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &SolverJob{
+		problemID: problemID,
+		problem:   problem,
+		solver:    s,
+		cancel:    cancel,
+		status:    SolverStatusScheduled,
+		done:      make(chan struct{}),
+	}
+	s.AddBestSolutionChangedListener(job.bestSolutionChanged)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		cancel()
+		return nil, ErrSolverManagerClosed
+	}
+	if _, ok := m.jobs[problemID]; ok {
+		cancel()
+		return nil, fmt.Errorf("%w: %s", ErrProblemExists, problemID)
+	}
+	m.jobs[problemID] = job
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run(ctx, job)
+		if m.finishedJobRetention > 0 {
+			time.AfterFunc(m.finishedJobRetention, func() { m.evict(job) })
+		}
+	}()
+	return job, nil
+}
+
+// evict 移除求解结束的任务，问题 ID 已被新的任务使用时不移除
+func (m *SolverManager) evict(job *SolverJob) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.jobs[job.problemID] == job {
+		delete(m.jobs, job.problemID)
+	}
+}
+
+func (m *SolverManager) run(ctx context.Context, job *SolverJob) {
+	defer job.cancel()
+	select {
+	case m.slots <- struct{}{}:
+		defer func() { <-m.slots }()
+	case <-ctx.Done():
+		// 排队期间被终止
+		job.finish(nil, nil)
+		return
+	}
+	if !job.start() {
+		job.finish(nil, nil)
+		return
+	}
+	best, err := job.solver.SolveContext(ctx, job.problem)
+	if errors.Is(err, context.Canceled) {
+		// 提前终止不是错误
+		err = nil
+	}
+	job.finish(best, err)
+}
+
+// GetSolverJob 按问题 ID 获取求解任务
+func (m *SolverManager) GetSolverJob(problemID string) (*SolverJob, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[problemID]
+	return job, ok
+}
+
+// GetSolverJobs 获取所有求解任务
+func (m *SolverManager) GetSolverJobs() []*SolverJob {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]*SolverJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// TerminateEarly 提前终止求解，保留目前的最佳解决方案，问题不存在时返回 false
+func (m *SolverManager) TerminateEarly(problemID string) bool {
+	job, ok := m.GetSolverJob(problemID)
+	if ok {
+		job.TerminateEarly()
+	}
+	return ok
+}
+
+// RemoveSolverJob 终止求解任务，等待求解结束后移除，问题不存在时返回 false
+// 求解结束前问题 ID 仍被占用，返回后可以使用相同的问题 ID 提交新问题
+func (m *SolverManager) RemoveSolverJob(problemID string) bool {
+	job, ok := m.GetSolverJob(problemID)
+	if !ok {
+		return false
+	}
+	job.TerminateEarly()
+	<-job.Done()
+	m.evict(job)
+	return true
+}
+
+// Close 终止所有求解任务并等待结束，关闭后不再接受新问题
+func (m *SolverManager) Close() {
+	m.mu.Lock()
+	m.closed = true
+	jobs := make([]*SolverJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+	m.mu.Unlock()
+	for _, job := range jobs {
+		job.TerminateEarly()
+	}
+	m.wg.Wait()
+}
+
+// SolverJob 单个问题的求解任务，方法可以在任意协程中调用
+type SolverJob struct {
+	problemID string
+	problem   api.ISolution
+	solver    *DefaultSolver
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	mu                sync.Mutex
+	status            SolverStatus
+	terminatedEarly   bool
+	bestScore         api.IScore
+	bestSolution      api.ISolution
+	terminationReason TerminationReason
+	err               error
+	startTime         time.Time
+	endTime           time.Time
+}
+
+func (j *SolverJob) GetProblemID() string {
+	return j.problemID
+}
+
+func (j *SolverJob) GetStatus() SolverStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// GetBestScore 获取目前的最佳分数，求解开始前为 nil
+func (j *SolverJob) GetBestScore() api.IScore {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.bestScore
+}
+
+// GetBestSolution 获取目前的最佳解决方案
+// 求解期间返回最佳解决方案的克隆，问题未实现 api.ICloneableSolution 时在求解结束前返回 false
+func (j *SolverJob) GetBestSolution() (api.ISolution, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.bestSolution, j.bestSolution != nil
+}
+
+// GetTerminationReason 获取求解终止的原因，求解结束前为 TerminationReasonNone
+func (j *SolverJob) GetTerminationReason() TerminationReason {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.terminationReason
+}
+
+// GetError 获取求解失败的原因，如检测到分数损坏
+func (j *SolverJob) GetError() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// GetSolvingDuration 获取已求解的时间，尚未开始时为 0
+func (j *SolverJob) GetSolvingDuration() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	switch {
+	case j.startTime.IsZero():
+		return 0
+	case j.endTime.IsZero():
+		return time.Since(j.startTime)
+	default:
+		return j.endTime.Sub(j.startTime)
+	}
+}
+
+// IsTerminatedEarly 是否调用了 TerminateEarly
+func (j *SolverJob) IsTerminatedEarly() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.terminatedEarly
+}
+
+// TerminateEarly 提前终止求解，排队中的任务不再开始
+func (j *SolverJob) TerminateEarly() {
+	j.mu.Lock()
+	if j.status != SolverStatusEnded {
+		j.terminatedEarly = true
+	}
+	active := j.status == SolverStatusActive
+	j.mu.Unlock()
+	if active {
+		j.solver.Stop()
+	}
+	j.cancel()
+}
+
+// AddProblemChange 添加问题变更，求解期间在当前步结束后应用
+func (j *SolverJob) AddProblemChange(change ProblemChange) {
+	j.solver.AddProblemChange(change)
+}
+
+// Done 求解结束时关闭的通道
+func (j *SolverJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait 等待求解结束并返回最佳解决方案与错误
+func (j *SolverJob) Wait() (api.ISolution, error) {
+	<-j.done
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.bestSolution, j.err
+}
+
+// start 开始求解，已被终止时返回 false
+func (j *SolverJob) start() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.terminatedEarly {
+		return false
+	}
+	j.status = SolverStatusActive
+	j.startTime = time.Now()
+	return true
+}
+
+// bestSolutionChanged 在求解协程中记录最佳分数，问题可克隆时保存最佳解决方案的克隆
+func (j *SolverJob) bestSolutionChanged(bestSolution api.ISolution, bestScore api.IScore) {
+	var clone api.ISolution
+	if cloneable, ok := bestSolution.(api.ICloneableSolution); ok {
+		clone = cloneable.CloneSolution()
+		clone.SetScore(bestScore)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.bestScore = bestScore
+	if clone != nil {
+		j.bestSolution = clone
+	}
+}
+
+func (j *SolverJob) finish(best api.ISolution, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status == SolverStatusActive {
+		j.terminationReason = j.solver.GetTerminationReason()
+	} else {
+		// 排队期间被终止，未开始求解
+		j.terminationReason = TerminationReasonStopped
+		j.startTime = time.Now()
+	}
+	j.status = SolverStatusEnded
+	j.endTime = time.Now()
+	j.err = err
+	if best != nil {
+		// 求解结束后不再修改，直接使用工作解决方案
+		j.bestSolution = best
+		j.bestScore = best.GetScore()
+	}
+	close(j.done)
+}
@@ -0,0 +1,44 @@
+package solver
+
+// TerminationReason 求解终止的原因
+type TerminationReason int
+
+const (
+	// 尚未求解或仍在求解
+	TerminationReasonNone TerminationReason = iota
+	// 所有阶段正常结束（如没有可选的移动）
+	TerminationReasonCompleted
+	// 满足终止条件
+	TerminationReasonTerminationCondition
+	// 达到 SolverConfig.TimeLimit
+	TerminationReasonTimeLimit
+	// 调用了 Stop
+	TerminationReasonStopped
+	// 调用方的上下文被取消
+	TerminationReasonCanceled
+	// 调用方的上下文超过截止时间
+	TerminationReasonDeadlineExceeded
+	// 断言环境模式检测到分数损坏
+	TerminationReasonScoreCorruption
+)
+
+func (r TerminationReason) String() string {
+	switch r {
+	case TerminationReasonCompleted:
+		return "COMPLETED"
+	case TerminationReasonTerminationCondition:
+		return "TERMINATION_CONDITION"
+	case TerminationReasonTimeLimit:
+		return "TIME_LIMIT"
+	case TerminationReasonStopped:
+		return "STOPPED"
+	case TerminationReasonCanceled:
+		return "CANCELED"
+	case TerminationReasonDeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case TerminationReasonScoreCorruption:
+		return "SCORE_CORRUPTION"
+	default:
+		return "NONE"
+	}
+}
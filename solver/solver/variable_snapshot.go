@@ -0,0 +1,35 @@
+package solver
+
+import (
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/solution"
+)
+
+// 可移动变量在某一时刻的值，用于恢复最佳解决方案或分区求解前的状态
+type variableSnapshot struct {
+	variables []api.IPlanningVariable
+	values    []interface{}
+}
+
+func snapshotVariables(workingSolution api.ISolution) *variableSnapshot {
+	snapshot := &variableSnapshot{}
+	for _, entity := range solution.GetMovablePlanningEntities(workingSolution) {
+		for _, variable := range entity.GetPlanningVariables() {
+			snapshot.variables = append(snapshot.variables, variable)
+			snapshot.values = append(snapshot.values, variable.GetValue())
+		}
+	}
+	return snapshot
+}
+
+// restore 恢复快照中的值，通知分数指导器以保持增量分数一致
+func (v *variableSnapshot) restore(scoreDirector api.IScoreDirector) {
+	for i, variable := range v.variables {
+		if solution.IsSameValue(variable.GetValue(), v.values[i]) {
+			continue
+		}
+		scoreDirector.BeforeVariableChanged(variable)
+		variable.SetValue(v.values[i])
+		scoreDirector.AfterVariableChanged(variable)
+	}
+}
@@ -3,6 +3,7 @@ package tabu
 import (
 	"github.com/kruily/go-timefold-solver/solver/api"
 	"github.com/kruily/go-timefold-solver/solver/config"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
 )
 
 type TabuSearchAcceptor struct {
@@ -37,7 +38,7 @@ func (t *TabuSearchAcceptor) Accept(move api.IMove, score api.IScore) (bool, err
 		return false, err
 	}
 	if t.currentScore != nil {
-		improvement := float64(t.currentScore.CompareTo(score))
+		improvement := scoredef.Difference(t.currentScore, score)
 		t.improvementRate = 0.9*t.improvementRate + 0.1*improvement
 		t.tabuList.adjustSize(improvement)
 	}
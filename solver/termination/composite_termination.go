@@ -0,0 +1,64 @@
+package termination
+
+import (
+	"errors"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// AndCompositeTermination 所有终止条件都满足时终止
+type AndCompositeTermination struct {
+	terminations []Termination
+}
+
+func NewAndCompositeTermination(terminations ...Termination) *AndCompositeTermination {
+	return &AndCompositeTermination{terminations: terminations}
+}
+
+func (t *AndCompositeTermination) IsTerminated(scope *Scope) bool {
+	if len(t.terminations) == 0 {
+		return false
+	}
+	for _, termination := range t.terminations {
+		if !termination.IsTerminated(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OrCompositeTermination 任一终止条件满足时终止
+type OrCompositeTermination struct {
+	terminations []Termination
+}
+
+func NewOrCompositeTermination(terminations ...Termination) *OrCompositeTermination {
+	return &OrCompositeTermination{terminations: terminations}
+}
+
+func (t *OrCompositeTermination) IsTerminated(scope *Scope) bool {
+	for _, termination := range t.terminations {
+		if termination.IsTerminated(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *AndCompositeTermination) CheckScoreType(score api.IScore) error {
+	return checkScoreTypes(t.terminations, score)
+}
+
+func (t *OrCompositeTermination) CheckScoreType(score api.IScore) error {
+	return checkScoreTypes(t.terminations, score)
+}
+
+func checkScoreTypes(terminations []Termination, score api.IScore) error {
+	errs := make([]error, 0)
+	for _, termination := range terminations {
+		if err := CheckScoreType(termination, score); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
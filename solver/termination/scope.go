@@ -0,0 +1,105 @@
+package termination
+
+import (
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// Scope 求解器或阶段的运行状态，供终止条件判断
+type Scope struct {
+	// 上级状态（阶段状态的上级为求解器状态），步数与移动数会同步累计到上级
+	parent *Scope
+
+	startTime           time.Time
+	stepCount           int
+	moveCount           int64
+	bestScore           api.IScore
+	lastImprovementStep int
+	lastImprovementTime time.Time
+}
+
+func NewScope() *Scope {
+	scope := &Scope{}
+	scope.Start(nil)
+	return scope
+}
+
+// NewChildScope 创建下级状态，用于阶段
+func NewChildScope(parent *Scope) *Scope {
+	scope := NewScope()
+	scope.parent = parent
+	return scope
+}
+
+// Start 以初始分数开始计时与计数
+func (s *Scope) Start(initialScore api.IScore) {
+	now := time.Now()
+	s.startTime = now
+	s.stepCount = 0
+	s.moveCount = 0
+	s.bestScore = initialScore
+	s.lastImprovementStep = 0
+	s.lastImprovementTime = now
+}
+
+// StepEnded 记录一步结束，分数优于最佳分数时记为改进
+func (s *Scope) StepEnded(stepScore api.IScore) {
+	if s.parent != nil {
+		s.parent.StepEnded(stepScore)
+	}
+	s.stepCount++
+	if stepScore == nil {
+		return
+	}
+	if s.bestScore == nil || stepScore.CompareTo(s.bestScore) > 0 {
+		s.bestScore = stepScore
+		s.lastImprovementStep = s.stepCount
+		s.lastImprovementTime = time.Now()
+	}
+}
+
+// ResetBestScore 问题变更后以新的分数作为最佳分数，未改进的步数与时间重新计算，已用时间与计数保留
+func (s *Scope) ResetBestScore(bestScore api.IScore) {
+	s.bestScore = bestScore
+	s.lastImprovementStep = s.stepCount
+	s.lastImprovementTime = time.Now()
+}
+
+// MoveEvaluated 记录一次移动评估
+func (s *Scope) MoveEvaluated() {
+	if s.parent != nil {
+		s.parent.MoveEvaluated()
+	}
+	s.moveCount++
+}
+
+func (s *Scope) GetStartTime() time.Time {
+	return s.startTime
+}
+
+func (s *Scope) GetTimeSpent() time.Duration {
+	return time.Since(s.startTime)
+}
+
+func (s *Scope) GetStepCount() int {
+	return s.stepCount
+}
+
+func (s *Scope) GetMoveCount() int64 {
+	return s.moveCount
+}
+
+func (s *Scope) GetBestScore() api.IScore {
+	return s.bestScore
+}
+
+// GetUnimprovedStepCount 自上次改进以来的步数
+func (s *Scope) GetUnimprovedStepCount() int {
+	return s.stepCount - s.lastImprovementStep
+}
+
+// GetUnimprovedTimeSpent 自上次改进以来的时间
+func (s *Scope) GetUnimprovedTimeSpent() time.Duration {
+	return time.Since(s.lastImprovementTime)
+}
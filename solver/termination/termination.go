@@ -0,0 +1,131 @@
+package termination
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+)
+
+// Termination 终止条件接口
+type Termination interface {
+	// IsTerminated 根据运行状态判断是否应当终止
+	IsTerminated(scope *Scope) bool
+}
+
+// ScoreTypeChecker 可选接口，依赖分数类型的终止条件实现，求解开始前检查与解决方案的分数类型一致
+type ScoreTypeChecker interface {
+	CheckScoreType(score api.IScore) error
+}
+
+// CheckScoreType 检查终止条件（包括组合中的子条件）与分数类型一致
+func CheckScoreType(termination Termination, score api.IScore) error {
+	if checker, ok := termination.(ScoreTypeChecker); ok {
+		return checker.CheckScoreType(score)
+	}
+	return nil
+}
+
+// TimeSpentTermination 运行时间达到限制时终止
+type TimeSpentTermination struct {
+	limit time.Duration
+}
+
+func NewTimeSpentTermination(limit time.Duration) *TimeSpentTermination {
+	return &TimeSpentTermination{limit: limit}
+}
+
+func (t *TimeSpentTermination) IsTerminated(scope *Scope) bool {
+	return scope.GetTimeSpent() >= t.limit
+}
+
+// UnimprovedTimeSpentTermination 最佳分数长时间未改进时终止
+type UnimprovedTimeSpentTermination struct {
+	limit time.Duration
+}
+
+func NewUnimprovedTimeSpentTermination(limit time.Duration) *UnimprovedTimeSpentTermination {
+	return &UnimprovedTimeSpentTermination{limit: limit}
+}
+
+func (t *UnimprovedTimeSpentTermination) IsTerminated(scope *Scope) bool {
+	return scope.GetUnimprovedTimeSpent() >= t.limit
+}
+
+// StepCountTermination 步数达到限制时终止
+type StepCountTermination struct {
+	limit int
+}
+
+func NewStepCountTermination(limit int) *StepCountTermination {
+	return &StepCountTermination{limit: limit}
+}
+
+func (t *StepCountTermination) IsTerminated(scope *Scope) bool {
+	return scope.GetStepCount() >= t.limit
+}
+
+// UnimprovedStepCountTermination 最佳分数连续多步未改进时终止
+type UnimprovedStepCountTermination struct {
+	limit int
+}
+
+func NewUnimprovedStepCountTermination(limit int) *UnimprovedStepCountTermination {
+	return &UnimprovedStepCountTermination{limit: limit}
+}
+
+func (t *UnimprovedStepCountTermination) IsTerminated(scope *Scope) bool {
+	return scope.GetUnimprovedStepCount() >= t.limit
+}
+
+// BestScoreTermination 最佳分数达到限制时终止
+type BestScoreTermination struct {
+	limit api.IScore
+}
+
+func NewBestScoreTermination(limit api.IScore) *BestScoreTermination {
+	return &BestScoreTermination{limit: limit}
+}
+
+// IsTerminated 最佳分数与限制的类型不一致时不终止，求解开始前由 CheckScoreType 报告
+func (t *BestScoreTermination) IsTerminated(scope *Scope) bool {
+	bestScore := scope.GetBestScore()
+	if bestScore == nil {
+		return false
+	}
+	c, err := scoredef.Compare(bestScore, t.limit)
+	return err == nil && c >= 0
+}
+
+func (t *BestScoreTermination) CheckScoreType(score api.IScore) error {
+	if err := scoredef.Check(score, t.limit); err != nil {
+		return fmt.Errorf("best score limit %s: %w", t.limit.ToShortString(), err)
+	}
+	return nil
+}
+
+// BestScoreFeasibleTermination 最佳分数可行时终止
+type BestScoreFeasibleTermination struct{}
+
+func NewBestScoreFeasibleTermination() *BestScoreFeasibleTermination {
+	return &BestScoreFeasibleTermination{}
+}
+
+func (t *BestScoreFeasibleTermination) IsTerminated(scope *Scope) bool {
+	bestScore := scope.GetBestScore()
+	return bestScore != nil && bestScore.IsFeasible()
+}
+
+// MoveCountTermination 移动评估次数达到限制时终止
+type MoveCountTermination struct {
+	limit int64
+}
+
+func NewMoveCountTermination(limit int64) *MoveCountTermination {
+	return &MoveCountTermination{limit: limit}
+}
+
+func (t *MoveCountTermination) IsTerminated(scope *Scope) bool {
+	return scope.GetMoveCount() >= t.limit
+}
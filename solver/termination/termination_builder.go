@@ -0,0 +1,49 @@
+package termination
+
+import (
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/config"
+)
+
+// Build 根据终止配置构建终止条件，未配置任何条件时返回 nil
+func Build(cfg config.TerminationConfig) Termination {
+	terminations := make([]Termination, 0)
+	if cfg.TimeLimit > 0 {
+		terminations = append(terminations, NewTimeSpentTermination(time.Duration(cfg.TimeLimit)*time.Second))
+	}
+	if cfg.UnimprovedTimeLimit > 0 {
+		terminations = append(terminations, NewUnimprovedTimeSpentTermination(time.Duration(cfg.UnimprovedTimeLimit)*time.Second))
+	}
+	if cfg.StepCountLimit > 0 {
+		terminations = append(terminations, NewStepCountTermination(cfg.StepCountLimit))
+	}
+	if cfg.UnimprovedStepCountLimit > 0 {
+		terminations = append(terminations, NewUnimprovedStepCountTermination(cfg.UnimprovedStepCountLimit))
+	}
+	if cfg.BestScoreLimit != nil {
+		terminations = append(terminations, NewBestScoreTermination(cfg.BestScoreLimit))
+	}
+	if cfg.BestScoreFeasible {
+		terminations = append(terminations, NewBestScoreFeasibleTermination())
+	}
+	if cfg.MoveCountLimit > 0 {
+		terminations = append(terminations, NewMoveCountTermination(cfg.MoveCountLimit))
+	}
+	for _, child := range cfg.Terminations {
+		if termination := Build(child); termination != nil {
+			terminations = append(terminations, termination)
+		}
+	}
+
+	switch len(terminations) {
+	case 0:
+		return nil
+	case 1:
+		return terminations[0]
+	}
+	if cfg.CompositionStyle == config.TerminationCompositionStyleAnd {
+		return NewAndCompositeTermination(terminations...)
+	}
+	return NewOrCompositeTermination(terminations...)
+}
@@ -0,0 +1,167 @@
+package termination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kruily/go-timefold-solver/solver/config"
+	score "github.com/kruily/go-timefold-solver/solver/score/har_soft_score"
+	"github.com/kruily/go-timefold-solver/solver/score/scoredef"
+	simple "github.com/kruily/go-timefold-solver/solver/score/simple_score"
+)
+
+// stepScope 执行 steps 步，每步评估 movesPerStep 次移动
+func stepScope(steps, movesPerStep int) *Scope {
+	scope := NewScope()
+	for range steps {
+		for range movesPerStep {
+			scope.MoveEvaluated()
+		}
+		scope.StepEnded(score.ZERO)
+	}
+	return scope
+}
+
+func TestAndCompositeTermination(t *testing.T) {
+	termination := NewAndCompositeTermination(NewStepCountTermination(2), NewMoveCountTermination(6))
+	tests := []struct {
+		steps, movesPerStep int
+		want                bool
+	}{
+		{1, 10, false},
+		{3, 1, false},
+		{2, 3, true},
+	}
+	for _, tt := range tests {
+		if got := termination.IsTerminated(stepScope(tt.steps, tt.movesPerStep)); got != tt.want {
+			t.Errorf("%d steps with %d moves each: got %v, want %v", tt.steps, tt.movesPerStep, got, tt.want)
+		}
+	}
+	if NewAndCompositeTermination().IsTerminated(stepScope(5, 5)) {
+		t.Errorf("empty AND composite terminated")
+	}
+}
+
+func TestOrCompositeTermination(t *testing.T) {
+	termination := NewOrCompositeTermination(NewStepCountTermination(2), NewMoveCountTermination(6))
+	tests := []struct {
+		steps, movesPerStep int
+		want                bool
+	}{
+		{1, 1, false},
+		{1, 10, true},
+		{3, 0, true},
+	}
+	for _, tt := range tests {
+		if got := termination.IsTerminated(stepScope(tt.steps, tt.movesPerStep)); got != tt.want {
+			t.Errorf("%d steps with %d moves each: got %v, want %v", tt.steps, tt.movesPerStep, got, tt.want)
+		}
+	}
+	if NewOrCompositeTermination().IsTerminated(stepScope(5, 5)) {
+		t.Errorf("empty OR composite terminated")
+	}
+}
+
+func TestChildScopePropagatesToParent(t *testing.T) {
+	parent := NewScope()
+	parent.Start(score.NewHardSoftScore(0, -2, 0))
+	parent.StepEnded(score.NewHardSoftScore(0, -1, 0))
+
+	child := NewChildScope(parent)
+	child.Start(score.NewHardSoftScore(0, -1, 0))
+	child.MoveEvaluated()
+	child.MoveEvaluated()
+	child.StepEnded(score.NewHardSoftScore(0, -1, 0))
+	child.StepEnded(score.NewHardSoftScore(0, 0, -3))
+
+	if got := child.GetStepCount(); got != 2 {
+		t.Errorf("child step count: got %d, want 2", got)
+	}
+	if got := child.GetMoveCount(); got != 2 {
+		t.Errorf("child move count: got %d, want 2", got)
+	}
+	// 开始下级状态不重置上级的计数
+	if got := parent.GetStepCount(); got != 3 {
+		t.Errorf("parent step count: got %d, want 3", got)
+	}
+	if got := parent.GetMoveCount(); got != 2 {
+		t.Errorf("parent move count: got %d, want 2", got)
+	}
+	if got := parent.GetBestScore().ToShortString(); got != "HardSoftScore[initScore=0, hardScore=0, softScore=-3]" {
+		t.Errorf("parent best score: got %s", got)
+	}
+	if got := parent.GetUnimprovedStepCount(); got != 0 {
+		t.Errorf("parent unimproved step count: got %d, want 0", got)
+	}
+
+	child.StepEnded(score.NewHardSoftScore(0, 0, -4))
+	if got := child.GetUnimprovedStepCount(); got != 1 {
+		t.Errorf("child unimproved step count: got %d, want 1", got)
+	}
+	if !NewUnimprovedStepCountTermination(1).IsTerminated(parent) {
+		t.Errorf("parent unimproved step count termination did not see the child's step")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	if termination := Build(config.TerminationConfig{}); termination != nil {
+		t.Errorf("empty config: got %T, want nil", termination)
+	}
+	if termination, ok := Build(config.TerminationConfig{StepCountLimit: 3}).(*StepCountTermination); !ok || termination.limit != 3 {
+		t.Errorf("single limit: got %#v, want *StepCountTermination with limit 3", termination)
+	}
+
+	or := Build(config.TerminationConfig{StepCountLimit: 2, MoveCountLimit: 6})
+	if _, ok := or.(*OrCompositeTermination); !ok {
+		t.Fatalf("default composition: got %T, want *OrCompositeTermination", or)
+	}
+	if !or.IsTerminated(stepScope(1, 6)) {
+		t.Errorf("OR composite did not terminate on the move count alone")
+	}
+
+	and := Build(config.TerminationConfig{StepCountLimit: 2, MoveCountLimit: 6, CompositionStyle: config.TerminationCompositionStyleAnd})
+	if _, ok := and.(*AndCompositeTermination); !ok {
+		t.Fatalf("AND composition: got %T, want *AndCompositeTermination", and)
+	}
+	if and.IsTerminated(stepScope(1, 6)) || !and.IsTerminated(stepScope(2, 3)) {
+		t.Errorf("AND composite did not require both limits")
+	}
+
+	// 嵌套：移动数达到 6 且（步数达到 2 或已可行）
+	nested := Build(config.TerminationConfig{
+		MoveCountLimit:   6,
+		CompositionStyle: config.TerminationCompositionStyleAnd,
+		Terminations: []config.TerminationConfig{
+			{StepCountLimit: 2, BestScoreFeasible: true},
+		},
+	})
+	infeasible := NewScope()
+	infeasible.Start(score.NewHardSoftScore(0, -1, 0))
+	for range 6 {
+		infeasible.MoveEvaluated()
+	}
+	if nested.IsTerminated(infeasible) {
+		t.Errorf("nested termination terminated on an infeasible score after 0 steps")
+	}
+	infeasible.StepEnded(score.NewHardSoftScore(0, -1, 0))
+	infeasible.StepEnded(score.NewHardSoftScore(0, -1, 0))
+	if !nested.IsTerminated(infeasible) {
+		t.Errorf("nested termination did not terminate after 2 steps and 6 moves")
+	}
+}
+
+func TestBuildChecksNestedScoreTypes(t *testing.T) {
+	termination := Build(config.TerminationConfig{
+		StepCountLimit: 10,
+		Terminations: []config.TerminationConfig{
+			{BestScoreLimit: simple.NewSimpleScore(0, 0)},
+		},
+	})
+	var mismatch *scoredef.ScoreTypeMismatchError
+	if err := CheckScoreType(termination, score.ZERO); !errors.As(err, &mismatch) {
+		t.Errorf("got error %v, want *ScoreTypeMismatchError", err)
+	}
+	if err := CheckScoreType(termination, simple.NewSimpleScore(0, -5)); err != nil {
+		t.Errorf("got error %v for a matching score type", err)
+	}
+}
@@ -0,0 +1,52 @@
+package valuerange
+
+import (
+	"math/rand"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// CompositeValueRange 按顺序拼接多个可数值范围
+type CompositeValueRange struct {
+	ranges []api.ICountableValueRange
+}
+
+func NewCompositeValueRange(ranges ...api.ICountableValueRange) *CompositeValueRange {
+	return &CompositeValueRange{ranges: ranges}
+}
+
+func (r *CompositeValueRange) GetSize() int64 {
+	var size int64
+	for _, child := range r.ranges {
+		size += child.GetSize()
+	}
+	return size
+}
+
+func (r *CompositeValueRange) Get(index int64) interface{} {
+	for _, child := range r.ranges {
+		size := child.GetSize()
+		if index < size {
+			return child.Get(index)
+		}
+		index -= size
+	}
+	return nil
+}
+
+func (r *CompositeValueRange) Contains(value interface{}) bool {
+	for _, child := range r.ranges {
+		if child.Contains(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CompositeValueRange) CreateIterator() api.IValueRangeIterator {
+	return newSequentialIterator(r)
+}
+
+func (r *CompositeValueRange) CreateRandomIterator(random *rand.Rand) api.IValueRangeIterator {
+	return newRandomIterator(r, random)
+}
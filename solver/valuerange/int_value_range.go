@@ -0,0 +1,97 @@
+package valuerange
+
+import (
+	"math/rand"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// IntValueRange 整数值范围 [from, to)，按步长递增
+type IntValueRange struct {
+	from int
+	to   int
+	step int
+}
+
+func NewIntValueRange(from, to int) *IntValueRange {
+	return NewIntValueRangeWithStep(from, to, 1)
+}
+
+func NewIntValueRangeWithStep(from, to, step int) *IntValueRange {
+	if step <= 0 {
+		step = 1
+	}
+	if to < from {
+		to = from
+	}
+	return &IntValueRange{from: from, to: to, step: step}
+}
+
+func (r *IntValueRange) GetSize() int64 {
+	return int64((r.to - r.from + r.step - 1) / r.step)
+}
+
+func (r *IntValueRange) Get(index int64) interface{} {
+	return r.from + int(index)*r.step
+}
+
+func (r *IntValueRange) Contains(value interface{}) bool {
+	v, ok := value.(int)
+	if !ok || v < r.from || v >= r.to {
+		return false
+	}
+	return (v-r.from)%r.step == 0
+}
+
+func (r *IntValueRange) CreateIterator() api.IValueRangeIterator {
+	return newSequentialIterator(r)
+}
+
+func (r *IntValueRange) CreateRandomIterator(random *rand.Rand) api.IValueRangeIterator {
+	return newRandomIterator(r, random)
+}
+
+// Int64ValueRange int64 值范围 [from, to)，按步长递增
+type Int64ValueRange struct {
+	from int64
+	to   int64
+	step int64
+}
+
+func NewInt64ValueRange(from, to int64) *Int64ValueRange {
+	return NewInt64ValueRangeWithStep(from, to, 1)
+}
+
+func NewInt64ValueRangeWithStep(from, to, step int64) *Int64ValueRange {
+	if step <= 0 {
+		step = 1
+	}
+	if to < from {
+		to = from
+	}
+	return &Int64ValueRange{from: from, to: to, step: step}
+}
+
+func (r *Int64ValueRange) GetSize() int64 {
+	return (r.to - r.from + r.step - 1) / r.step
+}
+
+func (r *Int64ValueRange) Get(index int64) interface{} {
+	return r.from + index*r.step
+}
+
+func (r *Int64ValueRange) Contains(value interface{}) bool {
+	v, ok := value.(int64)
+	if !ok || v < r.from || v >= r.to {
+		return false
+	}
+	return (v-r.from)%r.step == 0
+}
+
+func (r *Int64ValueRange) CreateIterator() api.IValueRangeIterator {
+	return newSequentialIterator(r)
+}
+
+func (r *Int64ValueRange) CreateRandomIterator(random *rand.Rand) api.IValueRangeIterator {
+	return newRandomIterator(r, random)
+}
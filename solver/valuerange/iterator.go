@@ -0,0 +1,45 @@
+package valuerange
+
+import (
+	"math/rand"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// 顺序迭代器 按下标遍历可数值范围
+type sequentialIterator struct {
+	valueRange api.ICountableValueRange
+	index      int64
+}
+
+func newSequentialIterator(valueRange api.ICountableValueRange) *sequentialIterator {
+	return &sequentialIterator{valueRange: valueRange}
+}
+
+func (i *sequentialIterator) HasNext() bool {
+	return i.index < i.valueRange.GetSize()
+}
+
+func (i *sequentialIterator) Next() interface{} {
+	value := i.valueRange.Get(i.index)
+	i.index++
+	return value
+}
+
+// 随机迭代器 每次随机选择一个值
+type randomIterator struct {
+	valueRange api.ICountableValueRange
+	random     *rand.Rand
+}
+
+func newRandomIterator(valueRange api.ICountableValueRange, random *rand.Rand) *randomIterator {
+	return &randomIterator{valueRange: valueRange, random: random}
+}
+
+func (i *randomIterator) HasNext() bool {
+	return i.valueRange.GetSize() > 0
+}
+
+func (i *randomIterator) Next() interface{} {
+	return i.valueRange.Get(i.random.Int63n(i.valueRange.GetSize()))
+}
@@ -0,0 +1,51 @@
+package valuerange
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// ListValueRange 由值列表构成的值范围
+type ListValueRange struct {
+	values []interface{}
+}
+
+func NewListValueRange(values ...interface{}) *ListValueRange {
+	return &ListValueRange{values: values}
+}
+
+func (r *ListValueRange) GetSize() int64 {
+	return int64(len(r.values))
+}
+
+func (r *ListValueRange) Get(index int64) interface{} {
+	return r.values[index]
+}
+
+// Contains 值不可比较（如切片、映射）时按 reflect.DeepEqual 判断
+func (r *ListValueRange) Contains(value interface{}) bool {
+	for _, v := range r.values {
+		if equalValues(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues 值是否相等，不可比较的值（如切片、映射）按 reflect.DeepEqual 判断，避免 == 比较时 panic
+func equalValues(a, b interface{}) bool {
+	if t := reflect.TypeOf(b); t != nil && !t.Comparable() {
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
+
+func (r *ListValueRange) CreateIterator() api.IValueRangeIterator {
+	return newSequentialIterator(r)
+}
+
+func (r *ListValueRange) CreateRandomIterator(random *rand.Rand) api.IValueRangeIterator {
+	return newRandomIterator(r, random)
+}
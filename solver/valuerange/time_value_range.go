@@ -0,0 +1,50 @@
+package valuerange
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// TimeValueRange 时间值范围 [from, to)，按时长递增
+type TimeValueRange struct {
+	from time.Time
+	to   time.Time
+	step time.Duration
+}
+
+func NewTimeValueRange(from, to time.Time, step time.Duration) *TimeValueRange {
+	if step <= 0 {
+		step = time.Minute
+	}
+	if to.Before(from) {
+		to = from
+	}
+	return &TimeValueRange{from: from, to: to, step: step}
+}
+
+func (r *TimeValueRange) GetSize() int64 {
+	span := r.to.Sub(r.from)
+	return int64((span + r.step - 1) / r.step)
+}
+
+func (r *TimeValueRange) Get(index int64) interface{} {
+	return r.from.Add(time.Duration(index) * r.step)
+}
+
+func (r *TimeValueRange) Contains(value interface{}) bool {
+	v, ok := value.(time.Time)
+	if !ok || v.Before(r.from) || !v.Before(r.to) {
+		return false
+	}
+	return v.Sub(r.from)%r.step == 0
+}
+
+func (r *TimeValueRange) CreateIterator() api.IValueRangeIterator {
+	return newSequentialIterator(r)
+}
+
+func (r *TimeValueRange) CreateRandomIterator(random *rand.Rand) api.IValueRangeIterator {
+	return newRandomIterator(r, random)
+}
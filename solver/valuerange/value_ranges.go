@@ -0,0 +1,63 @@
+package valuerange
+
+import (
+	"math/rand"
+
+	"github.com/kruily/go-timefold-solver/solver/api"
+)
+
+// Of 获取实体上规划变量的值范围
+// 实体实现 api.IEntityValueRangeProvider 时使用实体特定的值范围，否则使用变量自身的值范围
+func Of(entity api.IPlanningEntity, variable api.IPlanningVariable) api.IValueRange {
+	if provider, ok := entity.(api.IEntityValueRangeProvider); ok {
+		if valueRange := provider.GetValueRange(variable); valueRange != nil {
+			return valueRange
+		}
+	}
+	return variable.GetValueRange()
+}
+
+// ToSlice 获取值范围中的所有值
+func ToSlice(valueRange api.IValueRange) []interface{} {
+	values := make([]interface{}, 0)
+	if valueRange == nil {
+		return values
+	}
+	iterator := valueRange.CreateIterator()
+	for iterator.HasNext() {
+		values = append(values, iterator.Next())
+	}
+	return values
+}
+
+// Contains 值范围是否包含指定值
+func Contains(valueRange api.IValueRange, value interface{}) bool {
+	if valueRange == nil {
+		return false
+	}
+	if countable, ok := valueRange.(api.ICountableValueRange); ok {
+		return countable.Contains(value)
+	}
+	iterator := valueRange.CreateIterator()
+	for iterator.HasNext() {
+		if equalValues(iterator.Next(), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// RandomValue 从值范围中随机选择一个值，值范围为空时返回 false
+func RandomValue(valueRange api.IValueRange, random *rand.Rand) (interface{}, bool) {
+	if countable, ok := valueRange.(api.ICountableValueRange); ok {
+		if countable.GetSize() == 0 {
+			return nil, false
+		}
+		return countable.Get(random.Int63n(countable.GetSize())), true
+	}
+	values := ToSlice(valueRange)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[random.Intn(len(values))], true
+}